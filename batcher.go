@@ -0,0 +1,249 @@
+package ring
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBatcherClosed is returned when adding to a Batcher that is closed.
+var ErrBatcherClosed = errors.New("ring: batcher closed")
+
+// OverflowPolicy specifies what a Batcher does when an item is added while
+// its Ring is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes Add wait until a flush makes room for the item.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest makes Add overwrite the oldest buffered item.
+	OverflowDropOldest
+)
+
+// Clock tells the time and creates timers for a Batcher. It allows tests to
+// control time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine after duration d, unless the
+	// returned Timer is stopped first.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by a Clock.
+type Timer interface {
+	// Stop prevents the timer from calling its function. It returns false if
+	// the timer already expired or was stopped.
+	Stop() bool
+}
+
+// realClock is a Clock using the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// BatcherConfig configures a Batcher.
+type BatcherConfig[T any] struct {
+	// Capacity is the number of items the Batcher buffers. It must be
+	// positive.
+	Capacity int
+	// MaxBatch is the maximum number of items passed to Flush at once. When
+	// this many items are buffered, they are flushed. If zero, or greater
+	// than Capacity, it is the same as Capacity.
+	MaxBatch int
+	// MaxLatency is the longest time an item is buffered before the Batcher
+	// flushes it. If zero, items are only flushed by size or by calling Flush
+	// or Close.
+	MaxLatency time.Duration
+	// Overflow specifies what Add does when the Batcher is full.
+	Overflow OverflowPolicy
+	// Clock provides the time and timers used for MaxLatency. If nil, the time
+	// package is used.
+	Clock Clock
+	// Flush is called with each batch of items, oldest first. The batch is
+	// only valid until Flush returns. Calls to Flush are never concurrent.
+	// Flush must not call methods of the Batcher.
+	Flush func(batch []T)
+}
+
+// Batcher collects items in a Ring, and passes them in batches to a Flush
+// function when either MaxBatch items are buffered or the oldest item has been
+// buffered for MaxLatency. A Batcher is safe for concurrent use.
+type Batcher[T any] struct {
+	cfg     BatcherConfig[T]
+	mu      sync.Mutex
+	space   *sync.Cond // signaled when items are flushed or Batcher closed
+	r       *Ring[T]
+	added   *Ring[time.Time] // time each item in r was added
+	timer   Timer
+	gen     uint64 // incremented each time timer is started
+	dropped uint64
+	closed  bool
+
+	flushMu sync.Mutex // serializes flushes and protects scratch
+	scratch []T
+}
+
+// NewBatcher creates a new Batcher with the specified configuration. If the
+// configuration has no Flush function, or a Capacity that is not positive,
+// the call panics.
+func NewBatcher[T any](cfg BatcherConfig[T]) *Batcher[T] {
+	if cfg.Capacity <= 0 {
+		panic("ring: batcher capacity must be positive")
+	}
+	if cfg.Flush == nil {
+		panic("ring: batcher requires Flush function")
+	}
+	if cfg.MaxBatch <= 0 || cfg.MaxBatch > cfg.Capacity {
+		cfg.MaxBatch = cfg.Capacity
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = realClock{}
+	}
+	b := &Batcher[T]{
+		cfg:     cfg,
+		r:       New[T](cfg.Capacity),
+		added:   New[time.Time](cfg.Capacity),
+		scratch: make([]T, cfg.MaxBatch),
+	}
+	b.space = sync.NewCond(&b.mu)
+	return b
+}
+
+// Add adds an item to the Batcher. If this makes MaxBatch items buffered, Add
+// flushes them before returning. If the Batcher is full, Add either waits for
+// room or drops the oldest item, according to the Overflow policy. Add returns
+// ErrBatcherClosed if the Batcher is closed.
+func (b *Batcher[T]) Add(item T) error {
+	b.mu.Lock()
+	for b.cfg.Overflow == OverflowBlock && b.r.Full() && !b.closed {
+		b.space.Wait()
+	}
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	if b.r.Full() {
+		b.dropped++
+	}
+	b.r.PushBack(item)
+	b.added.PushBack(b.cfg.Clock.Now())
+	b.startTimer()
+	full := b.r.Len() >= b.cfg.MaxBatch
+	b.mu.Unlock()
+
+	if full {
+		b.flush(false)
+	}
+	return nil
+}
+
+// Len returns the number of items buffered.
+func (b *Batcher[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.r.Len()
+}
+
+// Dropped returns the number of items dropped by the OverflowDropOldest
+// policy.
+func (b *Batcher[T]) Dropped() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Flush flushes all buffered items.
+func (b *Batcher[T]) Flush() {
+	b.flush(true)
+}
+
+// Close flushes all buffered items and closes the Batcher. Calls to Add that
+// are waiting for room return ErrBatcherClosed. Calling Close more than once
+// does nothing.
+func (b *Batcher[T]) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	b.stopTimer()
+	b.space.Broadcast()
+	b.mu.Unlock()
+
+	b.flush(true)
+}
+
+// flush passes buffered items to the Flush function, in batches of at most
+// MaxBatch items. If all is false, flush stops when fewer than MaxBatch items
+// remain.
+func (b *Batcher[T]) flush(all bool) {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	for {
+		b.mu.Lock()
+		n := b.r.Len()
+		if n == 0 || (!all && n < b.cfg.MaxBatch) {
+			b.mu.Unlock()
+			return
+		}
+		batch := b.scratch[:min(n, b.cfg.MaxBatch)]
+		b.r.CopyTo(batch)
+		b.r.TrimFront(len(batch))
+		b.added.TrimFront(len(batch))
+		b.stopTimer()
+		if b.r.Len() != 0 && !b.closed {
+			b.startTimer()
+		}
+		b.space.Broadcast()
+		b.mu.Unlock()
+
+		b.cfg.Flush(batch)
+		clear(batch)
+	}
+}
+
+// startTimer starts the latency timer, if it is not already running, to expire
+// when the oldest buffered item has been buffered for MaxLatency. It must be
+// called with mu held, and with at least one item buffered.
+func (b *Batcher[T]) startTimer() {
+	if b.cfg.MaxLatency <= 0 || b.timer != nil {
+		return
+	}
+	d := max(b.cfg.MaxLatency-b.cfg.Clock.Now().Sub(b.added.Front()), 0)
+	b.gen++
+	gen := b.gen
+	b.timer = b.cfg.Clock.AfterFunc(d, func() {
+		b.expire(gen)
+	})
+}
+
+// stopTimer stops the latency timer. It must be called with mu held.
+func (b *Batcher[T]) stopTimer() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+}
+
+// expire flushes all buffered items when the latency timer expires. A timer
+// that expired after it was stopped is ignored.
+func (b *Batcher[T]) expire(gen uint64) {
+	b.mu.Lock()
+	if b.timer == nil || gen != b.gen {
+		b.mu.Unlock()
+		return
+	}
+	b.timer = nil
+	b.mu.Unlock()
+
+	b.flush(true)
+}
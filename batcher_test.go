@@ -0,0 +1,363 @@
+package ring
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only changes when Advance is called.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Duration
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock   *fakeClock
+	when    time.Duration
+	f       func()
+	stopped bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Time{}.Add(c.now)
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, when: c.now + d, f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d, and calls the functions of timers
+// that expire, in the calling goroutine.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now += d
+	var expired []*fakeTimer
+	c.timers = slices.DeleteFunc(c.timers, func(t *fakeTimer) bool {
+		if t.when <= c.now {
+			expired = append(expired, t)
+			return true
+		}
+		return false
+	})
+	c.mu.Unlock()
+	for _, t := range expired {
+		t.f()
+	}
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(c.timers)
+	c.timers = slices.DeleteFunc(c.timers, func(x *fakeTimer) bool { return x == t })
+	return len(c.timers) != n
+}
+
+// batchRecorder records the batches passed to a Flush function.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []string
+}
+
+func (br *batchRecorder) flush(batch []int) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	br.batches = append(br.batches, fmt.Sprint(batch))
+}
+
+func (br *batchRecorder) String() string {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	return fmt.Sprint(br.batches)
+}
+
+func TestBatcherSize(t *testing.T) {
+	var rec batchRecorder
+	b := NewBatcher(BatcherConfig[int]{
+		Capacity: 8,
+		MaxBatch: 3,
+		Flush:    rec.flush,
+	})
+	for i := 1; i <= 7; i++ {
+		if err := b.Add(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := rec.String(); got != "[[1 2 3] [4 5 6]]" {
+		t.Fatal("wrong batches:", got)
+	}
+	if b.Len() != 1 {
+		t.Fatal("expected 1 item buffered, got", b.Len())
+	}
+	b.Flush()
+	b.Flush()
+	if got := rec.String(); got != "[[1 2 3] [4 5 6] [7]]" {
+		t.Fatal("wrong batches after flush:", got)
+	}
+}
+
+func TestBatcherLatency(t *testing.T) {
+	var rec batchRecorder
+	clock := &fakeClock{}
+	b := NewBatcher(BatcherConfig[int]{
+		Capacity:   8,
+		MaxBatch:   4,
+		MaxLatency: time.Second,
+		Clock:      clock,
+		Flush:      rec.flush,
+	})
+	b.Add(1)
+	clock.Advance(500 * time.Millisecond)
+	b.Add(2)
+	if got := rec.String(); got != "[]" {
+		t.Fatal("flushed before latency expired:", got)
+	}
+	clock.Advance(500 * time.Millisecond)
+	if got := rec.String(); got != "[[1 2]]" {
+		t.Fatal("wrong batches after latency expired:", got)
+	}
+
+	// Timer starts again with next item, and a flush by size stops it.
+	b.Add(3)
+	clock.Advance(100 * time.Millisecond)
+	for i := 4; i <= 6; i++ {
+		b.Add(i)
+	}
+	if got := rec.String(); got != "[[1 2] [3 4 5 6]]" {
+		t.Fatal("wrong batches after size flush:", got)
+	}
+	clock.Advance(time.Second)
+	if got := rec.String(); got != "[[1 2] [3 4 5 6]]" {
+		t.Fatal("stopped timer caused flush:", got)
+	}
+	if len(clock.timers) != 0 {
+		t.Fatal("expected no timers running")
+	}
+
+	b.Add(7)
+	b.Close()
+	if got := rec.String(); got != "[[1 2] [3 4 5 6] [7]]" {
+		t.Fatal("wrong batches after close:", got)
+	}
+	if len(clock.timers) != 0 {
+		t.Fatal("expected timer stopped by close")
+	}
+	if b.Add(8) != ErrBatcherClosed {
+		t.Fatal("expected error adding to closed batcher")
+	}
+	b.Close()
+}
+
+func TestBatcherLatencyAfterPartialFlush(t *testing.T) {
+	var rec batchRecorder
+	clock := &fakeClock{}
+	release := make(chan struct{})
+	b := NewBatcher(BatcherConfig[int]{
+		Capacity:   8,
+		MaxBatch:   2,
+		MaxLatency: time.Second,
+		Clock:      clock,
+		Flush: func(batch []int) {
+			rec.flush(batch)
+			if batch[0] == 1 {
+				<-release
+			}
+		},
+	})
+	b.Add(1)
+	go b.Add(2)
+	waitFor(t, func() bool { return rec.String() == "[[1 2]]" })
+
+	// Add items while the first batch is being flushed. The flushes by size
+	// started by adding them wait for the first flush, and leave 5 buffered.
+	var wg sync.WaitGroup
+	for i := 3; i <= 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b.Add(i)
+		}(i)
+		waitFor(t, func() bool { return b.Len() == i-2 })
+	}
+	clock.Advance(900 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if got := rec.String(); got != "[[1 2] [3 4]]" {
+		t.Fatal("wrong batches after size flush:", got)
+	}
+
+	// Item 5 has been buffered for 900ms, so it must be flushed 100ms later.
+	clock.Advance(100 * time.Millisecond)
+	if got := rec.String(); got != "[[1 2] [3 4] [5]]" {
+		t.Fatal("item not flushed after latency expired:", got)
+	}
+	b.Close()
+}
+
+// waitFor waits until cond returns true.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for start := time.Now(); !cond(); time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("timed out waiting for condition")
+		}
+	}
+}
+
+// slowBatcher returns a Batcher with capacity 2 that has flushed [1 2], and is
+// waiting in the Flush function until release is closed.
+func slowBatcher(t *testing.T, overflow OverflowPolicy, rec *batchRecorder) (*Batcher[int], chan struct{}) {
+	release := make(chan struct{})
+	b := NewBatcher(BatcherConfig[int]{
+		Capacity: 2,
+		Overflow: overflow,
+		Flush: func(batch []int) {
+			rec.flush(batch)
+			<-release
+		},
+	})
+	b.Add(1)
+	go b.Add(2)
+	waitFor(t, func() bool { return rec.String() == "[[1 2]]" })
+	return b, release
+}
+
+// back returns the newest item in the Batcher.
+func (b *Batcher[T]) back() (item T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.r.Len() != 0 {
+		item = b.r.Back()
+	}
+	return item
+}
+
+func TestBatcherDropOldest(t *testing.T) {
+	var rec batchRecorder
+	b, release := slowBatcher(t, OverflowDropOldest, &rec)
+
+	// Each Add that fills the batcher waits to flush, so add from separate
+	// goroutines, one at a time.
+	var wg sync.WaitGroup
+	for i := 3; i <= 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b.Add(i)
+		}(i)
+		waitFor(t, func() bool { return b.back() == i })
+	}
+	if b.Dropped() != 2 || b.Len() != 2 {
+		t.Fatalf("expected 2 dropped and 2 buffered, got %d and %d", b.Dropped(), b.Len())
+	}
+	close(release)
+	wg.Wait()
+	if got := rec.String(); got != "[[1 2] [5 6]]" {
+		t.Fatal("wrong batches:", got)
+	}
+}
+
+func TestBatcherBlock(t *testing.T) {
+	var rec batchRecorder
+	b, release := slowBatcher(t, OverflowBlock, &rec)
+
+	go b.Add(3)
+	waitFor(t, func() bool { return b.back() == 3 })
+	go b.Add(4)
+	waitFor(t, func() bool { return b.back() == 4 })
+
+	// Batcher is full, so Add waits until Close.
+	errc := make(chan error)
+	go func() {
+		errc <- b.Add(5)
+	}()
+	closed := make(chan struct{})
+	go func() {
+		b.Close()
+		close(closed)
+	}()
+	if err := <-errc; err != ErrBatcherClosed {
+		t.Fatal("expected ErrBatcherClosed from blocked Add, got", err)
+	}
+	close(release)
+	<-closed
+	if got := rec.String(); got != "[[1 2] [3 4]]" {
+		t.Fatal("wrong batches:", got)
+	}
+	if b.Dropped() != 0 {
+		t.Fatal("expected no dropped items")
+	}
+}
+
+func TestBatcherBlockUntilFlushed(t *testing.T) {
+	var rec batchRecorder
+	b, release := slowBatcher(t, OverflowBlock, &rec)
+	go b.Add(3)
+	go b.Add(4)
+	waitFor(t, func() bool { return b.Len() == 2 })
+
+	added := make(chan struct{})
+	go func() {
+		b.Add(5)
+		close(added)
+	}()
+	close(release)
+	<-added
+	b.Close()
+	if got := rec.String(); got != "[[1 2] [3 4] [5]]" && got != "[[1 2] [4 3] [5]]" {
+		t.Fatal("wrong batches:", got)
+	}
+}
+
+func TestBatcherConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var total int
+	b := NewBatcher(BatcherConfig[int]{
+		Capacity:   16,
+		MaxBatch:   5,
+		MaxLatency: time.Millisecond,
+		Flush: func(batch []int) {
+			if len(batch) > 5 {
+				t.Error("batch too large:", len(batch))
+			}
+			mu.Lock()
+			total += len(batch)
+			mu.Unlock()
+		},
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				b.Add(i)
+			}
+		}()
+	}
+	wg.Wait()
+	b.Close()
+	if total != 8000 {
+		t.Fatal("expected 8000 items flushed, got", total)
+	}
+}
+
+func TestNewBatcherPanics(t *testing.T) {
+	assertPanics(t, "should panic with zero capacity", func() {
+		NewBatcher(BatcherConfig[int]{Flush: func([]int) {}})
+	})
+	assertPanics(t, "should panic without Flush function", func() {
+		NewBatcher(BatcherConfig[int]{Capacity: 1})
+	})
+}
@@ -0,0 +1,324 @@
+package ring
+
+import (
+	"container/list"
+	cring "container/ring"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Benchmarks compare Ring with buffered channels, container/list,
+// container/ring, and slice-based queues, for several element sizes. Run a
+// single comparison with, for example:
+//
+//	go test -run=^$ -bench=BenchmarkSteadyState/struct64
+
+const benchSize = 1024
+
+// bigItem is a 64-byte element.
+type bigItem struct {
+	data [64]byte
+}
+
+// benchTypes runs a generic benchmark function for int, pointer, and 64-byte
+// struct elements.
+func benchTypes(b *testing.B, fi func(*testing.B, int), fp func(*testing.B, *int), fs func(*testing.B, bigItem)) {
+	b.Run("int", func(b *testing.B) { fi(b, 1) })
+	b.Run("pointer", func(b *testing.B) { fp(b, new(int)) })
+	b.Run("struct64", func(b *testing.B) { fs(b, bigItem{}) })
+}
+
+// BenchmarkSteadyState measures adding an element to the back and removing one
+// from the front of a half-full queue.
+func BenchmarkSteadyState(b *testing.B) {
+	benchTypes(b, steadyState[int], steadyState[*int], steadyState[bigItem])
+}
+
+func steadyState[T any](b *testing.B, v T) {
+	b.Run("Ring", func(b *testing.B) {
+		r := New[T](benchSize)
+		for i := 0; i < benchSize/2; i++ {
+			r.PushBack(v)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.PushBack(v)
+			v = r.PopFront()
+		}
+	})
+	b.Run("Channel", func(b *testing.B) {
+		ch := make(chan T, benchSize)
+		for i := 0; i < benchSize/2; i++ {
+			ch <- v
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ch <- v
+			v = <-ch
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		l := list.New()
+		for i := 0; i < benchSize/2; i++ {
+			l.PushBack(v)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			l.PushBack(v)
+			v = l.Remove(l.Front()).(T)
+		}
+	})
+	b.Run("ContainerRing", func(b *testing.B) {
+		cr := newCRingQueue[T](benchSize)
+		for i := 0; i < benchSize/2; i++ {
+			cr.pushBack(v)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cr.pushBack(v)
+			v = cr.popFront()
+		}
+	})
+	b.Run("Slice", func(b *testing.B) {
+		q := make([]T, benchSize/2, benchSize)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			q = append(q, v)
+			v = q[0]
+			q = q[1:]
+		}
+	})
+}
+
+// BenchmarkOverwrite measures adding an element to a full queue, removing the
+// oldest element to make room.
+func BenchmarkOverwrite(b *testing.B) {
+	benchTypes(b, overwrite[int], overwrite[*int], overwrite[bigItem])
+}
+
+func overwrite[T any](b *testing.B, v T) {
+	b.Run("Ring", func(b *testing.B) {
+		r := New[T](benchSize)
+		for i := 0; i < b.N; i++ {
+			r.PushBack(v)
+		}
+	})
+	b.Run("Channel", func(b *testing.B) {
+		ch := make(chan T, benchSize)
+		for i := 0; i < b.N; i++ {
+			select {
+			case ch <- v:
+			default:
+				<-ch
+				ch <- v
+			}
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		l := list.New()
+		for i := 0; i < b.N; i++ {
+			l.PushBack(v)
+			if l.Len() > benchSize {
+				l.Remove(l.Front())
+			}
+		}
+	})
+	b.Run("ContainerRing", func(b *testing.B) {
+		cr := cring.New(benchSize)
+		for i := 0; i < b.N; i++ {
+			cr.Value = v
+			cr = cr.Next()
+		}
+	})
+	b.Run("Slice", func(b *testing.B) {
+		q := make([]T, 0, benchSize)
+		for i := 0; i < b.N; i++ {
+			if len(q) == benchSize {
+				q = q[1:]
+			}
+			q = append(q, v)
+		}
+	})
+}
+
+// BenchmarkAt measures reading elements at random positions in a full queue.
+func BenchmarkAt(b *testing.B) {
+	benchTypes(b, at[int], at[*int], at[bigItem])
+}
+
+func at[T any](b *testing.B, v T) {
+	idx := rand.New(rand.NewSource(1)).Perm(benchSize)
+	b.Run("Ring", func(b *testing.B) {
+		r := New[T](benchSize)
+		for i := 0; i < benchSize*3/2; i++ {
+			r.PushBack(v)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			v = r.At(idx[i%benchSize])
+		}
+	})
+	b.Run("ContainerRing", func(b *testing.B) {
+		cr := cring.New(benchSize)
+		for i := 0; i < benchSize; i++ {
+			cr.Value = v
+			cr = cr.Next()
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			v = cr.Move(idx[i%benchSize]).Value.(T)
+		}
+	})
+	b.Run("Slice", func(b *testing.B) {
+		q := make([]T, benchSize)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			v = q[idx[i%benchSize]]
+		}
+	})
+}
+
+// BenchmarkInsertRemove measures inserting and removing an element in the
+// middle of a half-full queue.
+func BenchmarkInsertRemove(b *testing.B) {
+	benchTypes(b, insertRemove[int], insertRemove[*int], insertRemove[bigItem])
+}
+
+func insertRemove[T any](b *testing.B, v T) {
+	b.Run("Ring", func(b *testing.B) {
+		r := New[T](benchSize)
+		for i := 0; i < benchSize/2; i++ {
+			r.PushBack(v)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.Insert(benchSize/4, v)
+			v = r.Remove(benchSize / 4)
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		l := list.New()
+		for i := 0; i < benchSize/2; i++ {
+			l.PushBack(v)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			// Finding the middle of the list is part of the cost.
+			e := l.Front()
+			for j := 0; j < benchSize/4; j++ {
+				e = e.Next()
+			}
+			v = l.Remove(l.InsertBefore(v, e)).(T)
+		}
+	})
+	b.Run("Slice", func(b *testing.B) {
+		q := make([]T, benchSize/2, benchSize)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			q = slices.Insert(q, benchSize/4, v)
+			v = q[benchSize/4]
+			q = slices.Delete(q, benchSize/4, benchSize/4+1)
+		}
+	})
+}
+
+// BenchmarkRotateCompare measures rotating a queue by various distances. See
+// BenchmarkRotate for a comparison of Rotate on rings with different amounts
+// of free space.
+func BenchmarkRotateCompare(b *testing.B) {
+	benchTypes(b, rotate[int], rotate[*int], rotate[bigItem])
+}
+
+func rotate[T any](b *testing.B, v T) {
+	for _, n := range []int{1, benchSize / 4, benchSize * 3 / 4} {
+		b.Run(fmt.Sprintf("n=%d/Ring", n), func(b *testing.B) {
+			// Partially filled ring, so elements must be moved.
+			r := New[T](benchSize)
+			for i := 0; i < benchSize*9/10; i++ {
+				r.PushBack(v)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.Rotate(n)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/ContainerRing", n), func(b *testing.B) {
+			cr := cring.New(benchSize)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cr = cr.Move(n)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/Slice", n), func(b *testing.B) {
+			q := make([]T, benchSize*9/10)
+			tmp := make([]T, n)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				copy(tmp, q[:n])
+				copy(q, q[n:])
+				copy(q[len(q)-n:], tmp)
+			}
+		})
+	}
+}
+
+// BenchmarkResize measures alternately doubling and halving the capacity of
+// a half-full queue.
+func BenchmarkResize(b *testing.B) {
+	benchTypes(b, resize[int], resize[*int], resize[bigItem])
+}
+
+func resize[T any](b *testing.B, v T) {
+	b.Run("Ring", func(b *testing.B) {
+		r := New[T](benchSize)
+		for i := 0; i < benchSize*3/4; i++ {
+			r.PushBack(v)
+		}
+		for i := 0; i < benchSize/4; i++ {
+			r.PopFront()
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if i%2 == 0 {
+				r.Resize(benchSize * 2)
+			} else {
+				r.Resize(benchSize)
+			}
+		}
+	})
+	b.Run("Slice", func(b *testing.B) {
+		q := make([]T, benchSize/2, benchSize)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			size := benchSize
+			if i%2 == 0 {
+				size *= 2
+			}
+			q = append(make([]T, 0, size), q...)
+		}
+	})
+}
+
+// cRingQueue is a FIFO queue built on container/ring.
+type cRingQueue[T any] struct {
+	head, tail *cring.Ring
+}
+
+func newCRingQueue[T any](size int) *cRingQueue[T] {
+	r := cring.New(size)
+	return &cRingQueue[T]{head: r, tail: r}
+}
+
+func (q *cRingQueue[T]) pushBack(v T) {
+	q.tail.Value = v
+	q.tail = q.tail.Next()
+}
+
+func (q *cRingQueue[T]) popFront() T {
+	v := q.head.Value.(T)
+	q.head.Value = nil
+	q.head = q.head.Next()
+	return v
+}
@@ -0,0 +1,250 @@
+package ring
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// frameOverhead is the number of bytes added to each record in a ByteRing: a
+// 4-byte length before the record, and the same length after it so that the
+// ByteRing can be traversed from back to front.
+const frameOverhead = 8
+
+// ByteRing is a fixed-size circular buffer of variable-length byte records.
+// Records are stored inline in a single byte arena, each framed by its length,
+// so that storing many small records does not require an allocation or a
+// slice header per record. Pushing a record onto a ByteRing that does not have
+// enough free space removes as many records as needed from the other end of
+// the ByteRing.
+type ByteRing struct {
+	buf     []byte
+	head    int // offset of front frame
+	tail    int // offset after back frame
+	used    int // bytes used by frames
+	count   int
+	scratch []byte
+}
+
+// NewByteRing creates a ByteRing whose arena holds size bytes. Each record
+// occupies its length plus 8 bytes of framing.
+func NewByteRing(size int) *ByteRing {
+	return &ByteRing{
+		buf: make([]byte, size),
+	}
+}
+
+// Cap returns the size of the ByteRing's arena in bytes. If b is nil,
+// b.Cap() is zero.
+func (b *ByteRing) Cap() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.buf)
+}
+
+// Len returns the number of records currently stored in the ByteRing. If b is
+// nil, b.Len() is zero.
+func (b *ByteRing) Len() int {
+	if b == nil {
+		return 0
+	}
+	return b.count
+}
+
+// Used returns the number of bytes of the arena used by the stored records,
+// including framing. If b is nil, b.Used() is zero.
+func (b *ByteRing) Used() int {
+	if b == nil {
+		return 0
+	}
+	return b.used
+}
+
+// PushBack copies a record to the back of the ByteRing. Removes records from
+// the front until there is enough space for the record. If the record and its
+// framing are larger than the arena, the call panics.
+func (b *ByteRing) PushBack(rec []byte) {
+	size := b.frameSize(rec)
+	for len(b.buf)-b.used < size {
+		b.dropFront()
+	}
+	b.writeFrame(b.tail, rec)
+	b.tail = (b.tail + size) % len(b.buf)
+	b.used += size
+	b.count++
+}
+
+// PushFront copies a record to the front of the ByteRing. Removes records from
+// the back until there is enough space for the record. If the record and its
+// framing are larger than the arena, the call panics.
+func (b *ByteRing) PushFront(rec []byte) {
+	size := b.frameSize(rec)
+	for len(b.buf)-b.used < size {
+		b.dropBack()
+	}
+	b.head = (b.head - size + len(b.buf)) % len(b.buf)
+	b.writeFrame(b.head, rec)
+	b.used += size
+	b.count++
+}
+
+// PopFront removes and returns the record from the front of the ByteRing. If
+// the ByteRing is empty, the call panics.
+func (b *ByteRing) PopFront() []byte {
+	if b.count <= 0 {
+		panic("PopFront called when empty")
+	}
+	rec := b.Front()
+	b.dropFront()
+	return rec
+}
+
+// PopBack removes and returns the record from the back of the ByteRing. If
+// the ByteRing is empty, the call panics.
+func (b *ByteRing) PopBack() []byte {
+	if b.count <= 0 {
+		panic("PopBack called when empty")
+	}
+	rec := b.Back()
+	b.dropBack()
+	return rec
+}
+
+// Front returns a copy of the record at the front of the ByteRing. This call
+// panics if the ByteRing is empty.
+func (b *ByteRing) Front() []byte {
+	if b.count <= 0 {
+		panic("Front called when empty")
+	}
+	rec := make([]byte, b.readLen(b.head))
+	b.read(b.head+4, rec)
+	return rec
+}
+
+// Back returns a copy of the record at the back of the ByteRing. This call
+// panics if the ByteRing is empty.
+func (b *ByteRing) Back() []byte {
+	if b.count <= 0 {
+		panic("Back called when empty")
+	}
+	n := b.readLen(b.tail - 4)
+	rec := make([]byte, n)
+	b.read(b.tail-4-n, rec)
+	return rec
+}
+
+// Range calls f for each record in the ByteRing, from front to back, until f
+// returns false. The slice passed to f is only valid until f returns, and must
+// not be modified.
+func (b *ByteRing) Range(f func(rec []byte) bool) {
+	off := b.head
+	for i := 0; i < b.Len(); i++ {
+		n := b.readLen(off)
+		if !f(b.record(off+4, n)) {
+			return
+		}
+		off = (off + n + frameOverhead) % len(b.buf)
+	}
+}
+
+// RangeReverse calls f for each record in the ByteRing, from back to front,
+// until f returns false. The slice passed to f is only valid until f returns,
+// and must not be modified.
+func (b *ByteRing) RangeReverse(f func(rec []byte) bool) {
+	off := b.tail
+	for i := 0; i < b.Len(); i++ {
+		n := b.readLen(off - 4)
+		off -= n + frameOverhead
+		if !f(b.record(off+4, n)) {
+			return
+		}
+		off = (off + len(b.buf)) % len(b.buf)
+	}
+}
+
+// Reset removes all records from the ByteRing, but it retains the arena for
+// use by future writes.
+func (b *ByteRing) Reset() {
+	b.head = 0
+	b.tail = 0
+	b.used = 0
+	b.count = 0
+}
+
+// frameSize returns the size of the frame holding rec, and panics if the
+// frame cannot fit in the arena.
+func (b *ByteRing) frameSize(rec []byte) int {
+	size := len(rec) + frameOverhead
+	if size > len(b.buf) {
+		panic(fmt.Sprintf("ring: record size %d exceeds available size %d", len(rec), len(b.buf)-frameOverhead))
+	}
+	return size
+}
+
+// dropFront removes the front frame.
+func (b *ByteRing) dropFront() {
+	size := b.readLen(b.head) + frameOverhead
+	b.head = (b.head + size) % len(b.buf)
+	b.used -= size
+	b.count--
+	if b.count == 0 {
+		b.Reset()
+	}
+}
+
+// dropBack removes the back frame.
+func (b *ByteRing) dropBack() {
+	size := b.readLen(b.tail-4) + frameOverhead
+	b.tail = (b.tail - size + len(b.buf)) % len(b.buf)
+	b.used -= size
+	b.count--
+	if b.count == 0 {
+		b.Reset()
+	}
+}
+
+// writeFrame writes rec, framed by its length, at offset off.
+func (b *ByteRing) writeFrame(off int, rec []byte) {
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(rec)))
+	b.write(off, n[:])
+	b.write(off+4, rec)
+	b.write(off+4+len(rec), n[:])
+}
+
+// readLen reads the record length stored at offset off.
+func (b *ByteRing) readLen(off int) int {
+	var n [4]byte
+	b.read(off, n[:])
+	return int(binary.LittleEndian.Uint32(n[:]))
+}
+
+// record returns the n bytes at offset off. If the bytes wrap around the end
+// of the arena, they are copied into a scratch buffer.
+func (b *ByteRing) record(off, n int) []byte {
+	off = (off + len(b.buf)) % len(b.buf)
+	if off+n <= len(b.buf) {
+		return b.buf[off : off+n]
+	}
+	if cap(b.scratch) < n {
+		b.scratch = make([]byte, n)
+	}
+	rec := b.scratch[:n]
+	b.read(off, rec)
+	return rec
+}
+
+// write copies p into the arena at offset off, wrapping around the end.
+func (b *ByteRing) write(off int, p []byte) {
+	off = (off + len(b.buf)) % len(b.buf)
+	n := copy(b.buf[off:], p)
+	copy(b.buf, p[n:])
+}
+
+// read copies bytes from the arena at offset off into p, wrapping around the
+// end.
+func (b *ByteRing) read(off int, p []byte) {
+	off = (off + len(b.buf)) % len(b.buf)
+	n := copy(p, b.buf[off:])
+	copy(p[n:], b.buf)
+}
@@ -0,0 +1,209 @@
+package ring
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func byteRingRecords(b *ByteRing) []string {
+	var recs []string
+	b.Range(func(rec []byte) bool {
+		recs = append(recs, string(rec))
+		return true
+	})
+	return recs
+}
+
+func TestByteRing(t *testing.T) {
+	b := NewByteRing(32)
+	if b.Cap() != 32 || b.Len() != 0 || b.Used() != 0 {
+		t.Fatal("wrong capacity, length, or usage for new byte ring")
+	}
+
+	b.PushBack([]byte("hello"))
+	b.PushBack([]byte("world"))
+	if b.Len() != 2 {
+		t.Fatalf("expected length 2, got %d", b.Len())
+	}
+	if b.Used() != 2*(5+frameOverhead) {
+		t.Fatalf("expected %d bytes used, got %d", 2*(5+frameOverhead), b.Used())
+	}
+	if string(b.Front()) != "hello" || string(b.Back()) != "world" {
+		t.Fatal("wrong front or back record")
+	}
+
+	// Needs 14 bytes, only 6 free, so evicts "hello".
+	b.PushBack([]byte("wrap!!"))
+	if got := fmt.Sprint(byteRingRecords(b)); got != "[world wrap!!]" {
+		t.Fatal("wrong records after eviction:", got)
+	}
+	if b.Used() != (5+frameOverhead)+(6+frameOverhead) {
+		t.Fatal("wrong usage after eviction:", b.Used())
+	}
+
+	// Evicts both existing records.
+	big := bytes.Repeat([]byte{'x'}, 24)
+	b.PushBack(big)
+	if b.Len() != 1 || !bytes.Equal(b.Front(), big) {
+		t.Fatal("expected only the large record")
+	}
+	if !bytes.Equal(b.PopFront(), big) {
+		t.Fatal("wrong record removed from front")
+	}
+	if b.Len() != 0 || b.Used() != 0 {
+		t.Fatal("expected empty byte ring")
+	}
+
+	b.PushBack(nil)
+	if b.Len() != 1 || len(b.PopBack()) != 0 {
+		t.Fatal("expected to store and remove empty record")
+	}
+
+	assertPanics(t, "should panic when record too large", func() {
+		b.PushBack(make([]byte, 25))
+	})
+	assertPanics(t, "should panic when removing from empty byte ring", func() {
+		b.PopFront()
+	})
+	assertPanics(t, "should panic when removing from empty byte ring", func() {
+		b.PopBack()
+	})
+	assertPanics(t, "should panic when peeking empty byte ring", func() {
+		b.Front()
+	})
+	assertPanics(t, "should panic when peeking empty byte ring", func() {
+		b.Back()
+	})
+}
+
+func TestByteRingPushFront(t *testing.T) {
+	b := NewByteRing(30)
+	b.PushFront([]byte("a"))
+	b.PushFront([]byte("bb"))
+	b.PushFront([]byte("ccc"))
+	if got := fmt.Sprint(byteRingRecords(b)); got != "[ccc bb a]" {
+		t.Fatal("wrong records:", got)
+	}
+	// Needs 12 bytes, only 0 free, so evicts "a" and "bb" from the back.
+	b.PushFront([]byte("dddd"))
+	if got := fmt.Sprint(byteRingRecords(b)); got != "[dddd ccc]" {
+		t.Fatal("wrong records after eviction:", got)
+	}
+	if string(b.PopBack()) != "ccc" || string(b.PopFront()) != "dddd" {
+		t.Fatal("wrong records removed")
+	}
+}
+
+func TestByteRingRange(t *testing.T) {
+	b := NewByteRing(64)
+	for i := 0; i < 20; i++ {
+		b.PushBack([]byte(fmt.Sprint("record", i)))
+	}
+
+	var fwd []string
+	b.Range(func(rec []byte) bool {
+		fwd = append(fwd, string(rec))
+		return true
+	})
+	var rev []string
+	b.RangeReverse(func(rec []byte) bool {
+		rev = append(rev, string(rec))
+		return true
+	})
+	if len(fwd) != b.Len() || len(rev) != b.Len() {
+		t.Fatal("range did not visit every record")
+	}
+	for i := range fwd {
+		if fwd[i] != rev[len(rev)-1-i] {
+			t.Fatal("reverse range not in reverse order")
+		}
+	}
+	if fwd[len(fwd)-1] != "record19" {
+		t.Fatal("expected newest record at back, got", fwd[len(fwd)-1])
+	}
+
+	var n int
+	b.Range(func(rec []byte) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Fatal("range did not stop when f returned false")
+	}
+	n = 0
+	b.RangeReverse(func(rec []byte) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Fatal("reverse range did not stop when f returned false")
+	}
+}
+
+func TestByteRingRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const size = 100
+	b := NewByteRing(size)
+	var model [][]byte
+	var used int
+
+	for i := 0; i < 10000; i++ {
+		rec := make([]byte, rng.Intn(30))
+		rng.Read(rec)
+		switch rng.Intn(4) {
+		case 0:
+			b.PushBack(rec)
+			for used+len(rec)+frameOverhead > size {
+				used -= len(model[0]) + frameOverhead
+				model = model[1:]
+			}
+			model = append(model, rec)
+			used += len(rec) + frameOverhead
+		case 1:
+			b.PushFront(rec)
+			for used+len(rec)+frameOverhead > size {
+				used -= len(model[len(model)-1]) + frameOverhead
+				model = model[:len(model)-1]
+			}
+			model = append([][]byte{rec}, model...)
+			used += len(rec) + frameOverhead
+		case 2:
+			if len(model) != 0 {
+				if !bytes.Equal(b.PopFront(), model[0]) {
+					t.Fatal("wrong record removed from front")
+				}
+				used -= len(model[0]) + frameOverhead
+				model = model[1:]
+			}
+		case 3:
+			if len(model) != 0 {
+				if !bytes.Equal(b.PopBack(), model[len(model)-1]) {
+					t.Fatal("wrong record removed from back")
+				}
+				used -= len(model[len(model)-1]) + frameOverhead
+				model = model[:len(model)-1]
+			}
+		}
+
+		if b.Len() != len(model) || b.Used() != used {
+			t.Fatalf("expected length %d and usage %d, got %d and %d", len(model), used, b.Len(), b.Used())
+		}
+		j := 0
+		b.Range(func(rec []byte) bool {
+			if !bytes.Equal(rec, model[j]) {
+				t.Fatalf("wrong record at index %d", j)
+			}
+			j++
+			return true
+		})
+		b.RangeReverse(func(rec []byte) bool {
+			j--
+			if !bytes.Equal(rec, model[j]) {
+				t.Fatalf("wrong record at index %d in reverse", j)
+			}
+			return true
+		})
+	}
+}
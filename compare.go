@@ -0,0 +1,48 @@
+package ring
+
+import "cmp"
+
+// Equal reports whether two Rings are equal: the same length and all elements
+// equal in front-to-back order, as with slices.Equal. The capacities of the
+// Rings are not compared. A nil Ring is equal to an empty Ring.
+func Equal[T comparable](r1, r2 *Ring[T]) bool {
+	return EqualFunc(r1, r2, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// EqualFunc reports whether two Rings are equal using an equality function on
+// each pair of elements, as with slices.EqualFunc. The capacities of the Rings
+// are not compared.
+func EqualFunc[T1, T2 any](r1 *Ring[T1], r2 *Ring[T2], eq func(T1, T2) bool) bool {
+	if r1.Len() != r2.Len() {
+		return false
+	}
+	for i := 0; i < r1.Len(); i++ {
+		if !eq(r1.buf[(r1.head+i)%len(r1.buf)], r2.buf[(r2.head+i)%len(r2.buf)]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare compares the elements of two Rings in front-to-back order, using
+// cmp.Compare on each pair of elements, as with slices.Compare. The result is
+// 0 if r1 == r2, -1 if r1 < r2, and +1 if r1 > r2.
+func Compare[T cmp.Ordered](r1, r2 *Ring[T]) int {
+	n := min(r1.Len(), r2.Len())
+	for i := 0; i < n; i++ {
+		c := cmp.Compare(r1.buf[(r1.head+i)%len(r1.buf)], r2.buf[(r2.head+i)%len(r2.buf)])
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(r1.Len(), r2.Len())
+}
+
+// Contains reports whether v is present in the Ring.
+func Contains[T comparable](r *Ring[T], v T) bool {
+	return r.Index(func(item T) bool {
+		return item == v
+	}) >= 0
+}
@@ -0,0 +1,95 @@
+package ring
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	r1 := New[int](4)
+	r2 := New[int](8)
+	if !Equal(r1, r2) {
+		t.Fatal("expected empty rings to be equal")
+	}
+	var nilRing *Ring[int]
+	if !Equal(r1, nilRing) || !Equal(nilRing, nilRing) {
+		t.Fatal("expected nil ring to equal empty ring")
+	}
+
+	for i := 0; i < 6; i++ {
+		r1.PushBack(i)
+	}
+	for i := 2; i < 6; i++ {
+		r2.PushBack(i)
+	}
+	if !Equal(r1, r2) {
+		t.Fatal("expected rings with same elements to be equal")
+	}
+	r2.Set(3, 9)
+	if Equal(r1, r2) {
+		t.Fatal("expected rings with different elements to not be equal")
+	}
+	r2.PopBack()
+	if Equal(r1, r2) {
+		t.Fatal("expected rings with different lengths to not be equal")
+	}
+
+	r3 := New[string](4)
+	for i := 2; i < 6; i++ {
+		r3.PushBack(strconv.Itoa(i))
+	}
+	eq := func(a int, b string) bool {
+		return strconv.Itoa(a) == b
+	}
+	if !EqualFunc(r1, r3, eq) {
+		t.Fatal("expected rings to be equal using EqualFunc")
+	}
+	r3.PushBack("6")
+	if EqualFunc(r1, r3, eq) {
+		t.Fatal("expected rings to not be equal using EqualFunc")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	r1 := New[int](4)
+	r2 := New[int](4)
+	if Compare(r1, r2) != 0 {
+		t.Fatal("expected empty rings to compare equal")
+	}
+	for _, x := range []int{1, 2, 3} {
+		r1.PushBack(x)
+		r2.PushBack(x)
+	}
+	if Compare(r1, r2) != 0 {
+		t.Fatal("expected equal rings to compare equal")
+	}
+	r2.PushBack(0)
+	if Compare(r1, r2) != -1 || Compare(r2, r1) != 1 {
+		t.Fatal("expected shorter ring to compare less")
+	}
+	r1.PushBack(1)
+	if Compare(r1, r2) != 1 || Compare(r2, r1) != -1 {
+		t.Fatal("expected ring with greater element to compare greater")
+	}
+	var nilRing *Ring[int]
+	if Compare(nilRing, r1) != -1 || Compare(nilRing, New[int](1)) != 0 {
+		t.Fatal("wrong comparison with nil ring")
+	}
+}
+
+func TestContains(t *testing.T) {
+	r := New[string](3)
+	for _, s := range []string{"a", "b", "c", "d"} {
+		r.PushBack(s)
+	}
+	if Contains(r, "a") {
+		t.Error("overwritten element should not be contained")
+	}
+	if !Contains(r, "d") || !Contains(r, "b") {
+		t.Error("expected ring to contain element")
+	}
+	var nilRing *Ring[string]
+	if Contains(nilRing, "") {
+		t.Error("nil ring should not contain anything")
+	}
+}
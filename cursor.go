@@ -0,0 +1,119 @@
+package ring
+
+// Cursor refers to an element of a Ring. Unlike an index used with At and Set,
+// which refers to a different element whenever items are added or removed at
+// the front of the Ring, a Cursor stays on the same element while it is used
+// to insert and remove elements around it.
+//
+// A Cursor is invalidated by any structural change to the Ring that is not
+// made through the Cursor itself, such as pushing, popping, inserting,
+// removing, rotating, sorting, or resizing. Using an invalidated Cursor
+// panics, instead of silently reading or writing the wrong element. Assigning
+// elements with Set or Swap does not invalidate a Cursor.
+type Cursor[T any] struct {
+	r    *Ring[T]
+	i    int
+	mods uint
+}
+
+// Cursor returns a Cursor that refers to the element at index i in the Ring.
+// If the index is invalid, the call panics.
+func (r *Ring[T]) Cursor(i int) *Cursor[T] {
+	if i < 0 || i >= r.Len() {
+		panic(outOfRangeText(i, r.Len()))
+	}
+	return &Cursor[T]{
+		r:    r,
+		i:    i,
+		mods: r.mods,
+	}
+}
+
+// Index returns the current index, in the Ring, of the element the Cursor
+// refers to.
+func (c *Cursor[T]) Index() int {
+	c.check()
+	return c.i
+}
+
+// Value returns the element the Cursor refers to.
+func (c *Cursor[T]) Value() T {
+	c.check()
+	return c.r.At(c.i)
+}
+
+// Set assigns item to the element the Cursor refers to.
+func (c *Cursor[T]) Set(item T) {
+	c.check()
+	c.r.Set(c.i, item)
+}
+
+// Next moves the Cursor to the next element in the Ring. If the Cursor is at
+// the back of the Ring, it moves to the front.
+func (c *Cursor[T]) Next() {
+	c.check()
+	if c.i++; c.i == c.r.count {
+		c.i = 0
+	}
+}
+
+// Prev moves the Cursor to the previous element in the Ring. If the Cursor is
+// at the front of the Ring, it moves to the back.
+func (c *Cursor[T]) Prev() {
+	c.check()
+	if c.i == 0 {
+		c.i = c.r.count
+	}
+	c.i--
+}
+
+// InsertBefore inserts item into the Ring before the element the Cursor refers
+// to. The Cursor continues to refer to the same element. Panics if the Ring is
+// full, since inserting would otherwise overwrite an element at one end of the
+// Ring.
+//
+// Complexity is the same as for Ring.Insert.
+func (c *Cursor[T]) InsertBefore(item T) {
+	c.check()
+	c.r.Insert(c.i, item)
+	c.i++
+	c.mods = c.r.mods
+}
+
+// InsertAfter inserts item into the Ring after the element the Cursor refers
+// to. The Cursor continues to refer to the same element. Panics if the Ring is
+// full.
+//
+// Complexity is the same as for Ring.Insert.
+func (c *Cursor[T]) InsertAfter(item T) {
+	c.check()
+	c.r.Insert(c.i+1, item)
+	c.mods = c.r.mods
+}
+
+// Remove removes and returns the element the Cursor refers to, and moves the
+// Cursor to the element that followed it. If the removed element was at the
+// back of the Ring, the Cursor moves to the front. After the last element is
+// removed, the Cursor cannot be used.
+//
+// Complexity is the same as for Ring.Remove.
+func (c *Cursor[T]) Remove() T {
+	c.check()
+	item := c.r.Remove(c.i)
+	if c.i == c.r.count {
+		c.i = 0
+	}
+	c.mods = c.r.mods
+	return item
+}
+
+// check panics if the Ring was structurally modified other than through the
+// Cursor, or if the Ring is empty.
+func (c *Cursor[T]) check() {
+	if c.mods != c.r.mods {
+		panic("ring: cursor used after ring was modified")
+	}
+	if c.r.count == 0 {
+		panic("ring: cursor used on empty ring")
+	}
+}
@@ -0,0 +1,138 @@
+package ring
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	r, _ := offsetRing(8, 5, 0)
+	for i := 0; i < 4; i++ {
+		r.PushBack(i)
+	}
+	c := r.Cursor(1)
+	if c.Index() != 1 || c.Value() != 1 {
+		t.Fatal("cursor does not refer to element 1")
+	}
+
+	c.InsertBefore(10)
+	c.InsertAfter(11)
+	if c.Index() != 2 || c.Value() != 1 {
+		t.Fatalf("cursor moved after insert: index %d value %d", c.Index(), c.Value())
+	}
+	if got := fmt.Sprint(r); got != "[0 10 1 11 2 3]" {
+		t.Fatal("wrong elements after insert:", got)
+	}
+
+	c.Set(20)
+	if r.At(2) != 20 {
+		t.Fatal("Set did not assign element")
+	}
+
+	if c.Remove() != 20 || c.Value() != 11 || c.Index() != 2 {
+		t.Fatal("cursor not at following element after Remove")
+	}
+
+	// Next and Prev wrap around.
+	c.Next()
+	c.Next()
+	if c.Value() != 3 {
+		t.Fatal("expected 3, got", c.Value())
+	}
+	c.Next()
+	if c.Index() != 0 || c.Value() != 0 {
+		t.Fatal("Next did not wrap to front")
+	}
+	c.Prev()
+	if c.Index() != r.Len()-1 || c.Value() != 3 {
+		t.Fatal("Prev did not wrap to back")
+	}
+
+	// Removing the back element moves to the front.
+	if c.Remove() != 3 || c.Index() != 0 || c.Value() != 0 {
+		t.Fatal("cursor not at front after removing back element")
+	}
+	for r.Len() != 0 {
+		c.Remove()
+	}
+	assertPanics(t, "should panic when ring is empty", func() {
+		c.Value()
+	})
+	assertPanics(t, "should panic when index out of range", func() {
+		r.Cursor(0)
+	})
+}
+
+func TestCursorFull(t *testing.T) {
+	r := New[int](3)
+	for i := 0; i < 3; i++ {
+		r.PushBack(i)
+	}
+	c := r.Cursor(1)
+	assertPanics(t, "should panic when inserting into full ring", func() {
+		c.InsertBefore(9)
+	})
+	assertPanics(t, "should panic when inserting into full ring", func() {
+		c.InsertAfter(9)
+	})
+	if c.Value() != 1 || r.Len() != 3 {
+		t.Fatal("failed insert changed cursor or ring")
+	}
+}
+
+func TestCursorInvalidated(t *testing.T) {
+	mods := map[string]func(r *Ring[int]){
+		"PushBack":    func(r *Ring[int]) { r.PushBack(9) },
+		"PushFront":   func(r *Ring[int]) { r.PushFront(9) },
+		"PopFront":    func(r *Ring[int]) { r.PopFront() },
+		"PopBack":     func(r *Ring[int]) { r.PopBack() },
+		"Insert":      func(r *Ring[int]) { r.Insert(1, 9) },
+		"Remove":      func(r *Ring[int]) { r.Remove(1) },
+		"InsertSlice": func(r *Ring[int]) { r.InsertSlice(1, 8, 9) },
+		"RemoveRange": func(r *Ring[int]) { r.RemoveRange(1, 2) },
+		"RemoveFunc":  func(r *Ring[int]) { r.RemoveFunc(func(x int) bool { return x == 2 }) },
+		"TrimFront":   func(r *Ring[int]) { r.TrimFront(1) },
+		"TrimBack":    func(r *Ring[int]) { r.TrimBack(1) },
+		"Rotate":      func(r *Ring[int]) { r.Rotate(1) },
+		"Reverse":     func(r *Ring[int]) { r.Reverse() },
+		"SortFunc":    func(r *Ring[int]) { r.SortFunc(cmp.Compare[int]) },
+		"Resize":      func(r *Ring[int]) { r.Resize(10) },
+		"Reset":       func(r *Ring[int]) { r.Reset() },
+		"OtherCursor": func(r *Ring[int]) { r.Cursor(0).Remove() },
+		"UnmarshalJSON": func(r *Ring[int]) {
+			if err := r.UnmarshalJSON([]byte(`{"cap":8,"items":[1,2,3]}`)); err != nil {
+				panic(err)
+			}
+		},
+	}
+	for name, mod := range mods {
+		t.Run(name, func(t *testing.T) {
+			r := New[int](8)
+			for i := 0; i < 5; i++ {
+				r.PushBack(i)
+			}
+			c := r.Cursor(2)
+			mod(r)
+			assertPanics(t, "should panic after "+name, func() {
+				c.Value()
+			})
+			assertPanics(t, "should panic after "+name, func() {
+				c.Next()
+			})
+		})
+	}
+
+	// Assigning elements does not invalidate a cursor.
+	r := New[int](8)
+	for i := 0; i < 5; i++ {
+		r.PushBack(i)
+	}
+	c := r.Cursor(2)
+	r.Set(2, 20)
+	r.Swap(0, 4)
+	r.RemoveFunc(func(x int) bool { return x > 100 })
+	if c.Value() != 20 {
+		t.Fatal("expected 20, got", c.Value())
+	}
+}
@@ -0,0 +1,7 @@
+//go:build ringdebug
+
+package ring
+
+// debug enables checking the invariants of a Ring after every method that
+// modifies it. Build with the ringdebug tag to enable.
+const debug = true
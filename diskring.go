@@ -0,0 +1,438 @@
+package ring
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// SyncPolicy specifies when a DiskRing flushes writes to stable storage.
+type SyncPolicy int
+
+const (
+	// SyncNone leaves flushing to the operating system. Call Sync to flush
+	// explicitly. Writes not yet flushed may be lost if the system crashes,
+	// but recovery always restores a consistent Ring.
+	SyncNone SyncPolicy = iota
+	// SyncAlways flushes each record and each metadata update before the
+	// writing method returns.
+	SyncAlways
+)
+
+const (
+	diskMagic   = 0x474e4952 // "RING"
+	diskVersion = 1
+
+	// File layout: header, two metadata slots, then record slots.
+	diskHeaderSize = 32
+	diskMetaSize   = 48
+	diskDataOffset = diskHeaderSize + 2*diskMetaSize
+
+	// Each record slot holds a sequence number, a checksum, and the record.
+	diskRecordHeaderSize = 12
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// DiskRing is a fixed-size circular buffer of fixed-size records stored in a
+// preallocated file, so that the contents survive a process restart. Pushing
+// a record onto a full DiskRing overwrites the record at the front.
+//
+// The head, count, and sequence metadata is written alternately to two
+// checksummed slots, so that a metadata update is atomic: after a crash the
+// most recent intact slot is used. Each record is stored with a checksum and
+// a sequence number. When the DiskRing is opened, records that were torn or
+// written after the last metadata update are discarded.
+//
+// A DiskRing is not safe for concurrent use.
+type DiskRing struct {
+	file    *os.File
+	recSize int
+	slots   int
+	sync    SyncPolicy
+
+	head  int
+	count int
+	seq   uint64 // sequence number of record at head
+	gen   uint64 // generation of last metadata written
+
+	scratch []byte
+}
+
+// diskMeta is the metadata stored in each of the two metadata slots.
+type diskMeta struct {
+	gen   uint64
+	head  uint64
+	count uint64
+	seq   uint64
+}
+
+// OpenDisk opens the DiskRing stored in the file at path, creating and
+// preallocating the file if it does not exist. An existing file must have
+// been created with the same record size and capacity. When an existing file
+// is opened, any torn or uncommitted records are discarded.
+func OpenDisk(path string, recordSize, capacity int, sync SyncPolicy) (*DiskRing, error) {
+	if recordSize <= 0 {
+		return nil, fmt.Errorf("ring: invalid record size %d", recordSize)
+	}
+	if capacity <= 0 {
+		return nil, fmt.Errorf("ring: invalid capacity %d", capacity)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	d := &DiskRing{
+		file:    f,
+		recSize: recordSize,
+		slots:   capacity,
+		sync:    sync,
+		scratch: make([]byte, diskRecordHeaderSize+recordSize),
+	}
+
+	blank, err := d.blank()
+	if err == nil {
+		if blank {
+			err = d.create()
+		} else {
+			err = d.recover()
+		}
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// Cap returns the maximum number of records the DiskRing can hold.
+func (d *DiskRing) Cap() int {
+	return d.slots
+}
+
+// Len returns the number of records currently stored in the DiskRing.
+func (d *DiskRing) Len() int {
+	return d.count
+}
+
+// RecordSize returns the size, in bytes, of each record.
+func (d *DiskRing) RecordSize() int {
+	return d.recSize
+}
+
+// PushBack appends a record to the back of the DiskRing. Wraps by overwriting
+// front when DiskRing is full. The record must be exactly RecordSize bytes.
+func (d *DiskRing) PushBack(rec []byte) error {
+	if len(rec) != d.recSize {
+		return fmt.Errorf("ring: record size %d, expected %d", len(rec), d.recSize)
+	}
+	slot := (d.head + d.count) % d.slots
+	if err := d.writeRecord(slot, d.seq+uint64(d.count), rec); err != nil {
+		return err
+	}
+
+	// If full, move head. Otherwise, increment count.
+	head, count, seq := d.head, d.count, d.seq
+	if count == d.slots {
+		head = (head + 1) % d.slots
+		seq++
+	} else {
+		count++
+	}
+	return d.writeMeta(head, count, seq)
+}
+
+// PopFront removes and returns the record from the front of the DiskRing. If
+// the DiskRing is empty, the call panics.
+func (d *DiskRing) PopFront() ([]byte, error) {
+	if d.count <= 0 {
+		panic("PopFront called when empty")
+	}
+	rec, err := d.readRecord(d.head, d.seq)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.writeMeta((d.head+1)%d.slots, d.count-1, d.seq+1); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Front returns the record at the front of the DiskRing. This call panics if
+// the DiskRing is empty.
+func (d *DiskRing) Front() ([]byte, error) {
+	if d.count <= 0 {
+		panic("Front called when empty")
+	}
+	return d.readRecord(d.head, d.seq)
+}
+
+// Back returns the record at the back of the DiskRing. This call panics if the
+// DiskRing is empty.
+func (d *DiskRing) Back() ([]byte, error) {
+	if d.count <= 0 {
+		panic("Back called when empty")
+	}
+	return d.At(d.count - 1)
+}
+
+// At returns the record at index i in the DiskRing without removing it. At(0)
+// refers to the front record. If the index is invalid, the call panics.
+func (d *DiskRing) At(i int) ([]byte, error) {
+	if i < 0 || i >= d.count {
+		panic(outOfRangeText(i, d.count))
+	}
+	return d.readRecord((d.head+i)%d.slots, d.seq+uint64(i))
+}
+
+// Reset removes all records from the DiskRing.
+func (d *DiskRing) Reset() error {
+	return d.writeMeta(d.head, 0, d.seq+uint64(d.count))
+}
+
+// Sync flushes all writes to stable storage.
+func (d *DiskRing) Sync() error {
+	return d.file.Sync()
+}
+
+// Close flushes all writes to stable storage and closes the file.
+func (d *DiskRing) Close() error {
+	err := d.file.Sync()
+	if cerr := d.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// blank reports whether the file is a new file that must be created. This is
+// true if the file is empty, or if it is no longer than the header and all
+// zeros, which is left by a crash while writing the header of a new file. Any
+// other file is opened as an existing DiskRing.
+func (d *DiskRing) blank() (bool, error) {
+	fi, err := d.file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if fi.Size() > diskHeaderSize {
+		return false, nil
+	}
+	var hdr [diskHeaderSize]byte
+	n, err := d.file.ReadAt(hdr[:], 0)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	for _, b := range hdr[:n] {
+		if b != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// create initializes a new file with a header and empty metadata, and then
+// extends the file to its full size. The header and metadata are synced
+// before the file is extended, so that a crash during creation leaves either
+// a blank file, which is created again when opened, or a file that is
+// recovered as an empty DiskRing. The file is never made smaller.
+func (d *DiskRing) create() error {
+	var hdr [diskHeaderSize]byte
+	binary.LittleEndian.PutUint32(hdr[0:], diskMagic)
+	binary.LittleEndian.PutUint32(hdr[4:], diskVersion)
+	binary.LittleEndian.PutUint64(hdr[8:], uint64(d.recSize))
+	binary.LittleEndian.PutUint64(hdr[16:], uint64(d.slots))
+	binary.LittleEndian.PutUint32(hdr[24:], crc32.Checksum(hdr[:24], crcTable))
+	if _, err := d.file.WriteAt(hdr[:], 0); err != nil {
+		return err
+	}
+	if err := d.writeMeta(0, 0, 0); err != nil {
+		return err
+	}
+	if err := d.file.Sync(); err != nil {
+		return err
+	}
+	fi, err := d.file.Stat()
+	if err != nil {
+		return err
+	}
+	size := int64(diskDataOffset) + int64(d.slots)*int64(len(d.scratch))
+	if fi.Size() < size {
+		if err = d.file.Truncate(size); err != nil {
+			return err
+		}
+	}
+	return d.file.Sync()
+}
+
+// recover reads the header and the most recent intact metadata, and then
+// discards any records that are not consistent with the metadata.
+func (d *DiskRing) recover() error {
+	var hdr [diskHeaderSize]byte
+	if _, err := d.file.ReadAt(hdr[:], 0); err != nil {
+		return fmt.Errorf("ring: cannot read header: %w", err)
+	}
+	if binary.LittleEndian.Uint32(hdr[0:]) != diskMagic {
+		return errors.New("ring: file is not a disk ring")
+	}
+	if crc32.Checksum(hdr[:24], crcTable) != binary.LittleEndian.Uint32(hdr[24:]) {
+		return errors.New("ring: corrupt header")
+	}
+	if v := binary.LittleEndian.Uint32(hdr[4:]); v != diskVersion {
+		return fmt.Errorf("ring: unsupported disk ring version %d", v)
+	}
+	recSize := binary.LittleEndian.Uint64(hdr[8:])
+	slots := binary.LittleEndian.Uint64(hdr[16:])
+	if recSize != uint64(d.recSize) || slots != uint64(d.slots) {
+		return fmt.Errorf("ring: file has record size %d and capacity %d, expected %d and %d",
+			recSize, slots, d.recSize, d.slots)
+	}
+
+	var meta diskMeta
+	var found bool
+	for i := 0; i < 2; i++ {
+		m, ok := d.readMeta(i)
+		if ok && (!found || m.gen > meta.gen) {
+			meta = m
+			found = true
+		}
+	}
+	if !found || meta.head >= slots || meta.count > slots {
+		return errors.New("ring: no valid metadata")
+	}
+	d.gen = meta.gen
+	d.head = int(meta.head)
+	d.count = int(meta.count)
+	d.seq = meta.seq
+
+	// Discard records at the front that do not match the metadata. This
+	// happens when an overwrite of the front record completed, or was torn,
+	// before the metadata was updated.
+	head, count, seq := d.head, d.count, d.seq
+	for count > 0 && !d.validRecord(head, seq) {
+		head = (head + 1) % d.slots
+		count--
+		seq++
+	}
+	// Discard everything from the first invalid record to the back.
+	for i := 0; i < count; i++ {
+		if !d.validRecord((head+i)%d.slots, seq+uint64(i)) {
+			count = i
+			break
+		}
+	}
+	if head == d.head && count == d.count {
+		return nil
+	}
+	if err := d.writeMeta(head, count, seq); err != nil {
+		return err
+	}
+	return d.file.Sync()
+}
+
+// readMeta reads metadata slot i and reports whether it is intact.
+func (d *DiskRing) readMeta(i int) (diskMeta, bool) {
+	var b [diskMetaSize]byte
+	if _, err := d.file.ReadAt(b[:], int64(diskHeaderSize+i*diskMetaSize)); err != nil {
+		return diskMeta{}, false
+	}
+	if crc32.Checksum(b[:32], crcTable) != binary.LittleEndian.Uint32(b[32:]) {
+		return diskMeta{}, false
+	}
+	return diskMeta{
+		gen:   binary.LittleEndian.Uint64(b[0:]),
+		head:  binary.LittleEndian.Uint64(b[8:]),
+		count: binary.LittleEndian.Uint64(b[16:]),
+		seq:   binary.LittleEndian.Uint64(b[24:]),
+	}, true
+}
+
+// writeMeta writes the next generation of metadata into the slot not holding
+// the current generation, and updates the DiskRing only if the write succeeds.
+func (d *DiskRing) writeMeta(head, count int, seq uint64) error {
+	gen := d.gen + 1
+	var b [diskMetaSize]byte
+	binary.LittleEndian.PutUint64(b[0:], gen)
+	binary.LittleEndian.PutUint64(b[8:], uint64(head))
+	binary.LittleEndian.PutUint64(b[16:], uint64(count))
+	binary.LittleEndian.PutUint64(b[24:], seq)
+	binary.LittleEndian.PutUint32(b[32:], crc32.Checksum(b[:32], crcTable))
+	if _, err := d.file.WriteAt(b[:], int64(diskHeaderSize+int(gen%2)*diskMetaSize)); err != nil {
+		return err
+	}
+	if d.sync == SyncAlways {
+		if err := d.file.Sync(); err != nil {
+			return err
+		}
+	}
+	d.gen = gen
+	d.head = head
+	d.count = count
+	d.seq = seq
+	return nil
+}
+
+// writeRecord writes a record and its sequence number into a slot.
+func (d *DiskRing) writeRecord(slot int, seq uint64, rec []byte) error {
+	b := d.scratch
+	binary.LittleEndian.PutUint64(b[0:], seq)
+	copy(b[diskRecordHeaderSize:], rec)
+	binary.LittleEndian.PutUint32(b[8:], d.recordChecksum(b))
+	if _, err := d.file.WriteAt(b, d.slotOffset(slot)); err != nil {
+		return err
+	}
+	if d.sync == SyncAlways {
+		return d.file.Sync()
+	}
+	return nil
+}
+
+// readRecord reads the record in a slot into a newly allocated slice, and
+// checks that the record is intact and has the expected sequence number.
+func (d *DiskRing) readRecord(slot int, seq uint64) ([]byte, error) {
+	if err := d.readSlot(slot); err != nil {
+		return nil, err
+	}
+	b := d.scratch
+	if binary.LittleEndian.Uint32(b[8:]) != d.recordChecksum(b) {
+		return nil, fmt.Errorf("ring: corrupt record in slot %d", slot)
+	}
+	if s := binary.LittleEndian.Uint64(b[0:]); s != seq {
+		return nil, fmt.Errorf("ring: record in slot %d has sequence %d, expected %d", slot, s, seq)
+	}
+	rec := make([]byte, d.recSize)
+	copy(rec, b[diskRecordHeaderSize:])
+	return rec, nil
+}
+
+// validRecord reports whether the slot holds an intact record with the
+// expected sequence number.
+func (d *DiskRing) validRecord(slot int, seq uint64) bool {
+	if d.readSlot(slot) != nil {
+		return false
+	}
+	b := d.scratch
+	return binary.LittleEndian.Uint32(b[8:]) == d.recordChecksum(b) &&
+		binary.LittleEndian.Uint64(b[0:]) == seq
+}
+
+// readSlot reads a record slot into the scratch buffer.
+func (d *DiskRing) readSlot(slot int) error {
+	_, err := d.file.ReadAt(d.scratch, d.slotOffset(slot))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// recordChecksum computes the checksum of the sequence number and data in a
+// record slot.
+func (d *DiskRing) recordChecksum(b []byte) uint32 {
+	crc := crc32.Checksum(b[:8], crcTable)
+	return crc32.Update(crc, crcTable, b[diskRecordHeaderSize:])
+}
+
+func (d *DiskRing) slotOffset(slot int) int64 {
+	return int64(diskDataOffset) + int64(slot)*int64(len(d.scratch))
+}
@@ -0,0 +1,308 @@
+package ring
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func diskRecord(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 8)
+}
+
+func checkDiskRing(t *testing.T, d *DiskRing, expect ...byte) {
+	t.Helper()
+	if d.Len() != len(expect) {
+		t.Fatalf("expected length %d, got %d", len(expect), d.Len())
+	}
+	for i, b := range expect {
+		rec, err := d.At(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(rec, diskRecord(b)) {
+			t.Fatalf("expected record %d at index %d, got %v", b, i, rec)
+		}
+	}
+}
+
+func TestDiskRing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	d, err := OpenDisk(path, 8, 4, SyncAlways)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Cap() != 4 || d.Len() != 0 || d.RecordSize() != 8 {
+		t.Fatal("wrong capacity, length, or record size for new disk ring")
+	}
+	for i := byte(1); i <= 6; i++ {
+		if err = d.PushBack(diskRecord(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkDiskRing(t, d, 3, 4, 5, 6)
+
+	rec, err := d.PopFront()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rec, diskRecord(3)) {
+		t.Fatal("wrong record removed from front")
+	}
+	rec, err = d.Front()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rec, diskRecord(4)) {
+		t.Fatal("wrong record at front")
+	}
+	rec, err = d.Back()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rec, diskRecord(6)) {
+		t.Fatal("wrong record at back")
+	}
+	if err = d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err = OpenDisk(path, 8, 4, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 4, 5, 6)
+	if err = d.PushBack(diskRecord(7)); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.PushBack(diskRecord(8)); err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 5, 6, 7, 8)
+	if err = d.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err = OpenDisk(path, 8, 4, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d)
+	if err = d.PushBack(diskRecord(9)); err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 9)
+	d.Close()
+}
+
+func TestDiskRingErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ring")
+	if _, err := OpenDisk(path, 0, 4, SyncNone); err == nil {
+		t.Error("expected error for invalid record size")
+	}
+	if _, err := OpenDisk(path, 8, 0, SyncNone); err == nil {
+		t.Error("expected error for invalid capacity")
+	}
+
+	d, err := OpenDisk(path, 8, 4, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = d.PushBack([]byte("short")); err == nil {
+		t.Error("expected error for wrong record size")
+	}
+	assertPanics(t, "should panic when removing from empty disk ring", func() {
+		d.PopFront()
+	})
+	assertPanics(t, "should panic when index out of range", func() {
+		d.At(0)
+	})
+	d.Close()
+
+	if _, err = OpenDisk(path, 16, 4, SyncNone); err == nil {
+		t.Error("expected error for mismatched record size")
+	}
+	if _, err = OpenDisk(path, 8, 5, SyncNone); err == nil {
+		t.Error("expected error for mismatched capacity")
+	}
+
+	other := filepath.Join(dir, "other")
+	if err = os.WriteFile(other, []byte("not a ring file at all, just some text"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = OpenDisk(other, 8, 4, SyncNone); err == nil {
+		t.Error("expected error for file that is not a disk ring")
+	}
+}
+
+func TestDiskRingRecoverUncommittedOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	d, err := OpenDisk(path, 8, 4, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := byte(1); i <= 4; i++ {
+		if err = d.PushBack(diskRecord(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Simulate a crash after a full ring overwrote its front record, but
+	// before the metadata was updated.
+	if err = d.writeRecord(d.head, d.seq+uint64(d.count), diskRecord(5)); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+
+	d, err = OpenDisk(path, 8, 4, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 2, 3, 4)
+	if err = d.PushBack(diskRecord(5)); err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 2, 3, 4, 5)
+	d.Close()
+}
+
+func TestDiskRingRecoverCreate(t *testing.T) {
+	const recSize, capacity = 8, 4
+	size := int64(diskDataOffset + capacity*(diskRecordHeaderSize+recSize))
+
+	// A file that is not a disk ring is rejected and left unchanged, even if
+	// it starts with zeros.
+	path := filepath.Join(t.TempDir(), "other")
+	other := make([]byte, 1<<20)
+	copy(other[100:], "some data")
+	if err := os.WriteFile(path, other, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenDisk(path, recSize, capacity, SyncNone); err == nil {
+		t.Fatal("expected error for file that is not a disk ring")
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, other) {
+		t.Fatal("file that is not a disk ring was modified")
+	}
+
+	// Simulate a crash while writing the header of a new file.
+	path = filepath.Join(t.TempDir(), "ring")
+	if err := os.WriteFile(path, make([]byte, diskHeaderSize), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := OpenDisk(path, recSize, capacity, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d)
+	if err = d.PushBack(diskRecord(1)); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+	d, err = OpenDisk(path, recSize, capacity, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 1)
+	d.Close()
+
+	// Simulate a crash after the header and metadata were written but before
+	// the file was extended.
+	path = filepath.Join(t.TempDir(), "ring")
+	d, err = OpenDisk(path, recSize, capacity, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+	if err = os.Truncate(path, diskDataOffset); err != nil {
+		t.Fatal(err)
+	}
+	d, err = OpenDisk(path, recSize, capacity, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d)
+	for i := byte(1); i <= 5; i++ {
+		if err = d.PushBack(diskRecord(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkDiskRing(t, d, 2, 3, 4, 5)
+	d.Close()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Size() != size {
+		t.Fatalf("expected file size %d, got %d", size, fi.Size())
+	}
+}
+
+func TestDiskRingRecoverTornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	d, err := OpenDisk(path, 8, 8, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := byte(1); i <= 5; i++ {
+		if err = d.PushBack(diskRecord(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	off := d.slotOffset(2) + diskRecordHeaderSize + 3
+	d.Close()
+
+	// Corrupt the third record.
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.WriteAt([]byte{0xff}, off); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	d, err = OpenDisk(path, 8, 8, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 1, 2)
+	d.Close()
+}
+
+func TestDiskRingRecoverTornMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	d, err := OpenDisk(path, 8, 4, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := byte(1); i <= 3; i++ {
+		if err = d.PushBack(diskRecord(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	off := int64(diskHeaderSize + int(d.gen%2)*diskMetaSize + 16)
+	d.Close()
+
+	// Corrupt the latest metadata, which recorded the third push.
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.WriteAt([]byte{0xff}, off); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	d, err = OpenDisk(path, 8, 4, SyncNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiskRing(t, d, 1, 2)
+	d.Close()
+}
@@ -0,0 +1,148 @@
+package ring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// binaryVersion is the version of the binary encoding written by
+// MarshalBinary.
+const binaryVersion = 1
+
+// MaxUnmarshalCapacity is the largest capacity of a Ring that UnmarshalJSON,
+// UnmarshalBinary, and GobDecode accept. Decoding a larger capacity returns an
+// error, instead of allocating a buffer as large as the encoded data asks for.
+const MaxUnmarshalCapacity = 1 << 24
+
+// ringJSON is the JSON representation of a Ring. Items are stored in logical
+// front-to-back order.
+type ringJSON[T any] struct {
+	Cap   int `json:"cap"`
+	Items []T `json:"items"`
+}
+
+// MarshalJSON implements json.Marshaler. The Ring is encoded as an object
+// containing its capacity and its items in front-to-back order, for example:
+// {"cap":10,"items":[1,2,3]}.
+//
+// MarshalJSON has a value receiver, so that a Ring is encoded the same whether
+// it is marshaled by value, such as in a struct field, or by pointer.
+func (r Ring[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ringJSON[T]{
+		Cap:   r.Cap(),
+		Items: r.items(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The Ring is replaced by a Ring
+// having the encoded capacity and items. An error is returned if the number
+// of items exceeds the capacity, or if the capacity exceeds
+// MaxUnmarshalCapacity.
+func (r *Ring[T]) UnmarshalJSON(data []byte) error {
+	var rj ringJSON[T]
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return err
+	}
+	return r.load(rj.Cap, rj.Items)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding consists of
+// a header, holding a version number and the capacity and length of the Ring,
+// followed by the gob encoding of the items in front-to-back order.
+func (r *Ring[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	hdr := make([]byte, 1, 1+2*binary.MaxVarintLen64)
+	hdr[0] = binaryVersion
+	hdr = binary.AppendUvarint(hdr, uint64(r.Cap()))
+	hdr = binary.AppendUvarint(hdr, uint64(r.Len()))
+	buf.Write(hdr)
+	if err := gob.NewEncoder(&buf).Encode(r.items()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The Ring is replaced
+// by a Ring having the encoded capacity and items. An error is returned if the
+// capacity exceeds MaxUnmarshalCapacity.
+func (r *Ring[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("ring: no binary data")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("ring: unsupported binary version %d", data[0])
+	}
+	rd := bytes.NewReader(data[1:])
+	capacity, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return fmt.Errorf("ring: cannot read capacity: %w", err)
+	}
+	length, err := binary.ReadUvarint(rd)
+	if err != nil {
+		return fmt.Errorf("ring: cannot read length: %w", err)
+	}
+	if length > capacity {
+		return fmt.Errorf("ring: length %d exceeds capacity %d", length, capacity)
+	}
+	if capacity > MaxUnmarshalCapacity {
+		return fmt.Errorf("ring: capacity %d exceeds maximum %d", capacity, MaxUnmarshalCapacity)
+	}
+	var items []T
+	if err = gob.NewDecoder(rd).Decode(&items); err != nil {
+		return fmt.Errorf("ring: cannot decode items: %w", err)
+	}
+	if uint64(len(items)) != length {
+		return fmt.Errorf("ring: decoded %d items, expected %d", len(items), length)
+	}
+	return r.load(int(capacity), items)
+}
+
+// GobEncode implements gob.GobEncoder using the same encoding as
+// MarshalBinary.
+func (r *Ring[T]) GobEncode() ([]byte, error) {
+	return r.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the same encoding as
+// UnmarshalBinary.
+func (r *Ring[T]) GobDecode(data []byte) error {
+	return r.UnmarshalBinary(data)
+}
+
+// items returns a newly allocated slice containing the items in the Ring in
+// front-to-back order.
+func (r *Ring[T]) items() []T {
+	items := make([]T, r.Len())
+	r.CopyTo(items)
+	return items
+}
+
+// load replaces the contents of the Ring with a new buffer of the given
+// capacity, holding items in front-to-back order.
+func (r *Ring[T]) load(capacity int, items []T) error {
+	if debug {
+		defer r.debugCheck()
+	}
+	if capacity < 0 {
+		return fmt.Errorf("ring: invalid capacity %d", capacity)
+	}
+	if capacity > MaxUnmarshalCapacity {
+		return fmt.Errorf("ring: capacity %d exceeds maximum %d", capacity, MaxUnmarshalCapacity)
+	}
+	if len(items) > capacity {
+		return fmt.Errorf("ring: %d items exceeds capacity %d", len(items), capacity)
+	}
+	r.mods++
+	r.buf = make([]T, capacity)
+	r.count = copy(r.buf, items)
+	r.head = 0
+	r.tail = 0
+	if capacity != 0 {
+		r.tail = r.count % capacity
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package ring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	r := New[int](5)
+	for i := 0; i < 7; i++ {
+		r.PushBack(i)
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expect = `{"cap":5,"items":[2,3,4,5,6]}`
+	if string(data) != expect {
+		t.Fatalf("expected %s, got %s", expect, string(data))
+	}
+
+	r = New[int](3)
+	data, err = json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"cap":3,"items":[]}` {
+		t.Fatal("wrong encoding for empty ring:", string(data))
+	}
+}
+
+func TestMarshalJSONValue(t *testing.T) {
+	type holder struct {
+		R Ring[int]
+	}
+	var h holder
+	h.R.PushBack(1)
+	h.R.PushBack(2)
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expect = `{"R":{"cap":16,"items":[1,2]}}`
+	if string(data) != expect {
+		t.Fatalf("expected %s, got %s", expect, string(data))
+	}
+	if data, err = json.Marshal(&h); err != nil || string(data) != expect {
+		t.Fatalf("expected %s by pointer, got %s", expect, string(data))
+	}
+
+	var h2 holder
+	if err = json.Unmarshal(data, &h2); err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(&h.R, &h2.R) || h2.R.Cap() != 16 {
+		t.Fatal("wrong ring after round trip:", &h2.R)
+	}
+
+	var nilRing *Ring[int]
+	if data, err = json.Marshal(nilRing); err != nil || string(data) != "null" {
+		t.Fatal("expected nil ring to encode as null, got", string(data))
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	r := New[string](4)
+	for _, s := range []string{"a", "b", "c", "d", "e", "f"} {
+		r.PushBack(s)
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r2 Ring[string]
+	if err = json.Unmarshal(data, &r2); err != nil {
+		t.Fatal(err)
+	}
+	if r2.Cap() != r.Cap() {
+		t.Fatalf("expected capacity %d, got %d", r.Cap(), r2.Cap())
+	}
+	if r2.Len() != r.Len() {
+		t.Fatalf("expected length %d, got %d", r.Len(), r2.Len())
+	}
+	for i := 0; i < r.Len(); i++ {
+		if r2.At(i) != r.At(i) {
+			t.Errorf("expected %s at index %d, got %s", r.At(i), i, r2.At(i))
+		}
+	}
+
+	// Restored ring must be full and overwrite the front.
+	r2.PushBack("g")
+	if r2.Front() != "d" || r2.Back() != "g" {
+		t.Fatal("restored ring did not overwrite front when full")
+	}
+
+	var r3 Ring[int]
+	if err = json.Unmarshal([]byte(`{"cap":3,"items":[1,2]}`), &r3); err != nil {
+		t.Fatal(err)
+	}
+	r3.PushBack(3)
+	r3.PushBack(4)
+	for i, x := range []int{2, 3, 4} {
+		if r3.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r3.At(i))
+		}
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	var r Ring[int]
+	if err := json.Unmarshal([]byte(`{"cap":2,"items":[1,2,3]}`), &r); err == nil {
+		t.Error("expected error when items exceed capacity")
+	}
+	if err := json.Unmarshal([]byte(`{"cap":-1,"items":[]}`), &r); err == nil {
+		t.Error("expected error for negative capacity")
+	}
+	if err := json.Unmarshal([]byte(`{"cap":2,"items":["x"]}`), &r); err == nil {
+		t.Error("expected error for wrong item type")
+	}
+	if err := json.Unmarshal([]byte(`{"cap":9000000000000000000,"items":[]}`), &r); err == nil {
+		t.Error("expected error for capacity exceeding maximum")
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprintf(`{"cap":%d,"items":[]}`, MaxUnmarshalCapacity+1)), &r); err == nil {
+		t.Error("expected error for capacity exceeding maximum")
+	}
+
+	// Zero-size items, so that the maximum capacity does not use memory.
+	var empty Ring[struct{}]
+	if err := json.Unmarshal([]byte(fmt.Sprintf(`{"cap":%d,"items":[{}]}`, MaxUnmarshalCapacity)), &empty); err != nil {
+		t.Error("expected maximum capacity to be accepted:", err)
+	}
+	if empty.Cap() != MaxUnmarshalCapacity || empty.Len() != 1 {
+		t.Error("wrong capacity or length for maximum capacity")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	r := New[int](8)
+	for i := 0; i < 20; i++ {
+		r.PushBack(i)
+		if i%3 == 0 {
+			r.PopFront()
+		}
+	}
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r2 Ring[int]
+	if err = r2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if r2.Cap() != r.Cap() || r2.Len() != r.Len() {
+		t.Fatalf("expected cap %d len %d, got cap %d len %d", r.Cap(), r.Len(), r2.Cap(), r2.Len())
+	}
+
+	// Restored ring must behave identically to the original.
+	for i := 100; i < 110; i++ {
+		r.PushBack(i)
+		r2.PushBack(i)
+		if r.PopFront() != r2.PopFront() {
+			t.Fatal("restored ring returned different value from PopFront")
+		}
+		for j := 0; j < r.Len(); j++ {
+			if r.At(j) != r2.At(j) {
+				t.Fatalf("expected %d at index %d, got %d", r.At(j), j, r2.At(j))
+			}
+		}
+	}
+
+	empty := New[string](3)
+	data, err = empty.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r3 Ring[string]
+	if err = r3.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if r3.Cap() != 3 || r3.Len() != 0 {
+		t.Fatal("wrong capacity or length for restored empty ring")
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	r := New[int](4)
+	r.PushBack(1)
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r2 Ring[int]
+	if err = r2.UnmarshalBinary(nil); err == nil {
+		t.Error("expected error for empty data")
+	}
+	bad := append([]byte{}, data...)
+	bad[0] = 99
+	if err = r2.UnmarshalBinary(bad); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+	bad = append([]byte{}, data...)
+	bad[2] = 5 // length greater than capacity
+	if err = r2.UnmarshalBinary(bad); err == nil {
+		t.Error("expected error for length exceeding capacity")
+	}
+	bad = append([]byte{}, data...)
+	bad[2] = 2 // length does not match encoded items
+	if err = r2.UnmarshalBinary(bad); err == nil {
+		t.Error("expected error for wrong number of items")
+	}
+	if err = r2.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Error("expected error for truncated data")
+	}
+
+	// Huge capacity followed by a valid empty item list.
+	for _, capacity := range []uint64{1 << 62, MaxUnmarshalCapacity + 1} {
+		if err = r2.UnmarshalBinary(binaryEncoding(t, capacity, []int{})); err == nil {
+			t.Errorf("expected error for capacity %d exceeding maximum", capacity)
+		}
+	}
+
+	// Zero-size items, so that the maximum capacity does not use memory.
+	var empty Ring[struct{}]
+	if err = empty.UnmarshalBinary(binaryEncoding(t, MaxUnmarshalCapacity, []struct{}{{}})); err != nil {
+		t.Error("expected maximum capacity to be accepted:", err)
+	}
+	if empty.Cap() != MaxUnmarshalCapacity || empty.Len() != 1 {
+		t.Error("wrong capacity or length for maximum capacity")
+	}
+}
+
+// binaryEncoding returns the binary encoding of a Ring with the specified
+// capacity and items, without checking the capacity.
+func binaryEncoding[T any](t *testing.T, capacity uint64, items []T) []byte {
+	t.Helper()
+	data := []byte{binaryVersion}
+	data = binary.AppendUvarint(data, capacity)
+	data = binary.AppendUvarint(data, uint64(len(items)))
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(items); err != nil {
+		t.Fatal(err)
+	}
+	return append(data, buf.Bytes()...)
+}
+
+func TestGob(t *testing.T) {
+	type snapshot struct {
+		Name   string
+		Events *Ring[string]
+	}
+	r := New[string](3)
+	for _, s := range []string{"a", "b", "c", "d"} {
+		r.PushBack(s)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(snapshot{Name: "x", Events: r}); err != nil {
+		t.Fatal(err)
+	}
+	var out snapshot
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "x" {
+		t.Fatal("wrong name decoded")
+	}
+	if out.Events.Cap() != 3 || out.Events.Len() != 3 {
+		t.Fatal("wrong capacity or length for decoded ring")
+	}
+	for i, s := range []string{"b", "c", "d"} {
+		if out.Events.At(i) != s {
+			t.Errorf("expected %s at index %d, got %s", s, i, out.Events.At(i))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package ring
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// String returns the elements of the Ring in front-to-back order, formatted
+// as a slice, for example: [1 2 3].
+func (r *Ring[T]) String() string {
+	return fmt.Sprint(r.items())
+}
+
+// GoString returns Go syntax that creates a Ring with the same capacity and
+// elements as r. This is used by the %#v format verb.
+func (r *Ring[T]) GoString() string {
+	typ := reflect.TypeOf((*T)(nil)).Elem().String()
+	if r == nil {
+		return fmt.Sprintf("(*ring.Ring[%s])(nil)", typ)
+	}
+	if r.Len() == 0 {
+		return fmt.Sprintf("ring.New[%s](%d)", typ, r.Cap())
+	}
+	return fmt.Sprintf("func() *ring.Ring[%s] { r := ring.New[%s](%d); r.InsertSlice(0, %#v...); return r }()",
+		typ, typ, r.Cap(), r.items())
+}
+
+// Format implements fmt.Formatter, so that a Ring is printed as its elements
+// in front-to-back order, instead of as its internal fields. The %v verb
+// prints the elements as a slice. The %+v verb also prints the length and
+// capacity of the Ring, and %#v prints the result of GoString. Other verbs
+// and flags are applied to the elements as they are when printing a slice.
+func (r *Ring[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, r.GoString())
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "{len:%d cap:%d items:%+v}", r.Len(), r.Cap(), r.items())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), r.items())
+	}
+}
@@ -0,0 +1,86 @@
+package ring
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	r := New[int](4)
+	if r.String() != "[]" {
+		t.Fatal("wrong string for empty ring:", r.String())
+	}
+	for i := 0; i < 6; i++ {
+		r.PushBack(i)
+	}
+	if r.String() != "[2 3 4 5]" {
+		t.Fatal("wrong string for ring:", r.String())
+	}
+	var nilRing *Ring[int]
+	if nilRing.String() != "[]" {
+		t.Fatal("wrong string for nil ring:", nilRing.String())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	r := New[int](4)
+	for i := 0; i < 6; i++ {
+		r.PushBack(i * 10)
+	}
+	tests := []struct {
+		format string
+		expect string
+	}{
+		{"%v", "[20 30 40 50]"},
+		{"%+v", "{len:4 cap:4 items:[20 30 40 50]}"},
+		{"%#v", "func() *ring.Ring[int] { r := ring.New[int](4); r.InsertSlice(0, []int{20, 30, 40, 50}...); return r }()"},
+		{"%d", "[20 30 40 50]"},
+		{"%x", "[14 1e 28 32]"},
+		{"%03d", "[020 030 040 050]"},
+		{"%s", "[%!s(int=20) %!s(int=30) %!s(int=40) %!s(int=50)]"},
+	}
+	for _, tc := range tests {
+		if got := fmt.Sprintf(tc.format, r); got != tc.expect {
+			t.Errorf("%s: expected %s, got %s", tc.format, tc.expect, got)
+		}
+	}
+
+	type point struct {
+		X, Y int
+	}
+	rp := New[point](3)
+	rp.PushBack(point{1, 2})
+	if got := fmt.Sprintf("%v", rp); got != "[{1 2}]" {
+		t.Error("wrong value format for struct ring:", got)
+	}
+	if got := fmt.Sprintf("%+v", rp); got != "{len:1 cap:3 items:[{X:1 Y:2}]}" {
+		t.Error("wrong field format for struct ring:", got)
+	}
+
+	rs := New[string](2)
+	rs.PushBack("a")
+	if got := fmt.Sprintf("%q", rs); got != `["a"]` {
+		t.Error("wrong quoted format for string ring:", got)
+	}
+}
+
+func TestGoString(t *testing.T) {
+	var nilRing *Ring[string]
+	if got := fmt.Sprintf("%#v", nilRing); got != "(*ring.Ring[string])(nil)" {
+		t.Error("wrong GoString for nil ring:", got)
+	}
+	r := New[string](5)
+	if got := r.GoString(); got != "ring.New[string](5)" {
+		t.Error("wrong GoString for empty ring:", got)
+	}
+	r.PushBack("x")
+	r.PushFront("w")
+	expect := `func() *ring.Ring[string] { r := ring.New[string](5); r.InsertSlice(0, []string{"w", "x"}...); return r }()`
+	if got := r.GoString(); got != expect {
+		t.Error("wrong GoString for ring:", got)
+	}
+	ra := New[any](2)
+	if got := ra.GoString(); got != "ring.New[interface {}](2)" {
+		t.Error("wrong GoString for ring of interface type:", got)
+	}
+}
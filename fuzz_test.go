@@ -0,0 +1,230 @@
+package ring
+
+import (
+	"slices"
+	"testing"
+)
+
+// refRing is a slice-based reference implementation of Ring, with the same
+// overwrite semantics, used to check the results of Ring operations.
+type refRing struct {
+	items []int
+	cap   int
+}
+
+// full reports whether adding an item overwrites an item. A refRing with zero
+// capacity allocates the default capacity when an item is added.
+func (rr *refRing) full() bool {
+	return rr.cap != 0 && len(rr.items) == rr.cap
+}
+
+func (rr *refRing) pushBack(x int) {
+	if rr.cap == 0 {
+		rr.cap = defaultCapacity
+	}
+	if len(rr.items) == rr.cap {
+		rr.items = rr.items[1:]
+	}
+	rr.items = append(rr.items, x)
+}
+
+func (rr *refRing) pushFront(x int) {
+	if rr.cap == 0 {
+		rr.cap = defaultCapacity
+	}
+	if len(rr.items) == rr.cap {
+		rr.items = rr.items[:len(rr.items)-1]
+	}
+	rr.items = append([]int{x}, rr.items...)
+}
+
+func (rr *refRing) rotate(n int) {
+	if len(rr.items) <= 1 {
+		return
+	}
+	k := ((n % len(rr.items)) + len(rr.items)) % len(rr.items)
+	rr.items = append(rr.items[k:], rr.items[:k]...)
+}
+
+func (rr *refRing) resize(newSize int, policy ResizePolicy) []int {
+	var evicted []int
+	if len(rr.items) > newSize {
+		if policy == KeepNewest {
+			evicted = slices.Clone(rr.items[:len(rr.items)-newSize])
+			rr.items = rr.items[len(rr.items)-newSize:]
+		} else {
+			evicted = slices.Clone(rr.items[newSize:])
+			rr.items = rr.items[:newSize]
+		}
+	}
+	rr.cap = newSize
+	return evicted
+}
+
+// didPanic reports whether f panics.
+func didPanic(f func()) (panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+	f()
+	return false
+}
+
+// FuzzRing decodes the fuzz input into a sequence of operations, applies them
+// to a Ring and to a refRing, and checks that every observable result is the
+// same. Each operation is one byte selecting the operation followed by one
+// byte argument.
+func FuzzRing(f *testing.F) {
+	f.Add([]byte{8, 0, 1, 0, 2, 0, 3, 0})
+	f.Add([]byte{4, 0, 1, 1, 0, 2, 0, 3, 0, 4, 4, 5, 6, 5, 3, 6, 251, 7, 3, 8, 1})
+	f.Add([]byte{2, 0, 10, 0, 20, 0, 30, 1, 40, 6, 2, 4, 1, 9, 7, 5, 0, 9, 1, 3, 0})
+	f.Add([]byte{16, 0, 1, 0, 2, 0, 3, 0, 4, 0, 5, 6, 7, 9, 3, 5, 2, 8, 0, 7, 9})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) == 0 {
+			return
+		}
+		size := int(data[0] % 17)
+		r := New[int](size)
+		ref := &refRing{cap: size}
+
+		for i := 1; i+1 < len(data); i += 2 {
+			op, arg := data[i], int(data[i+1])
+			n := len(ref.items)
+			switch op % 10 {
+			case 0:
+				r.PushBack(arg)
+				ref.pushBack(arg)
+			case 1:
+				r.PushFront(arg)
+				ref.pushFront(arg)
+			case 2:
+				if n == 0 {
+					if !didPanic(func() { r.PopFront() }) {
+						t.Fatal("PopFront did not panic when empty")
+					}
+					break
+				}
+				if x := r.PopFront(); x != ref.items[0] {
+					t.Fatalf("PopFront returned %d, expected %d", x, ref.items[0])
+				}
+				ref.items = ref.items[1:]
+			case 3:
+				if n == 0 {
+					if !didPanic(func() { r.PopBack() }) {
+						t.Fatal("PopBack did not panic when empty")
+					}
+					break
+				}
+				if x := r.PopBack(); x != ref.items[n-1] {
+					t.Fatalf("PopBack returned %d, expected %d", x, ref.items[n-1])
+				}
+				ref.items = ref.items[:n-1]
+			case 4:
+				at := arg%(n+3) - 1
+				if at < 0 || at > n || ref.full() {
+					if !didPanic(func() { r.Insert(at, arg) }) {
+						t.Fatalf("Insert(%d) did not panic with length %d and capacity %d", at, n, ref.cap)
+					}
+					break
+				}
+				r.Insert(at, arg)
+				if ref.cap == 0 {
+					ref.cap = defaultCapacity
+				}
+				ref.items = slices.Insert(ref.items, at, arg)
+			case 5:
+				at := arg%(n+2) - 1
+				if at < 0 || at >= n {
+					if !didPanic(func() { r.Remove(at) }) {
+						t.Fatalf("Remove(%d) did not panic with length %d", at, n)
+					}
+					break
+				}
+				if x := r.Remove(at); x != ref.items[at] {
+					t.Fatalf("Remove(%d) returned %d, expected %d", at, x, ref.items[at])
+				}
+				ref.items = slices.Delete(ref.items, at, at+1)
+			case 6:
+				r.Rotate(int(int8(arg)))
+				ref.rotate(int(int8(arg)))
+			case 7:
+				newSize := arg % 17
+				policy := ResizePolicy(arg / 16 % 2)
+				var evicted []int
+				r.ResizeFunc(newSize, policy, func(x int) {
+					evicted = append(evicted, x)
+				})
+				if expect := ref.resize(newSize, policy); !slices.Equal(evicted, expect) {
+					t.Fatalf("ResizeFunc evicted %v, expected %v", evicted, expect)
+				}
+			case 8:
+				at := arg%(n+2) - 1
+				if at < 0 || at >= n {
+					if !didPanic(func() { r.Set(at, arg) }) {
+						t.Fatalf("Set(%d) did not panic with length %d", at, n)
+					}
+					break
+				}
+				r.Set(at, arg)
+				ref.items[at] = arg
+			case 9:
+				at := arg%(n+2) - 1
+				if at < 0 || at >= n {
+					if !didPanic(func() { r.At(at) }) {
+						t.Fatalf("At(%d) did not panic with length %d", at, n)
+					}
+				}
+			}
+			checkRef(t, r, ref)
+		}
+	})
+}
+
+// checkRef checks that all observable state of the Ring matches the refRing.
+func checkRef(t *testing.T, r *Ring[int], ref *refRing) {
+	t.Helper()
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	n := len(ref.items)
+	if r.Len() != n {
+		t.Fatalf("Len() is %d, expected %d", r.Len(), n)
+	}
+	if r.Cap() != ref.cap {
+		t.Fatalf("Cap() is %d, expected %d", r.Cap(), ref.cap)
+	}
+	if r.Full() != ref.full() {
+		t.Fatalf("Full() is %v with length %d and capacity %d", r.Full(), n, ref.cap)
+	}
+	for i, x := range ref.items {
+		if r.At(i) != x {
+			t.Fatalf("At(%d) is %d, expected %d", i, r.At(i), x)
+		}
+	}
+	if n == 0 {
+		if !didPanic(func() { r.Front() }) || !didPanic(func() { r.Back() }) {
+			t.Fatal("Front or Back did not panic when empty")
+		}
+		return
+	}
+	if r.Front() != ref.items[0] || r.Back() != ref.items[n-1] {
+		t.Fatalf("Front() and Back() are %d and %d, expected %d and %d",
+			r.Front(), r.Back(), ref.items[0], ref.items[n-1])
+	}
+	x := ref.items[n/2]
+	eq := func(item int) bool {
+		return item == x
+	}
+	if idx := r.Index(eq); idx != slices.Index(ref.items, x) {
+		t.Fatalf("Index of %d is %d, expected %d", x, idx, slices.Index(ref.items, x))
+	}
+	var ridx int
+	for ridx = n - 1; ref.items[ridx] != x; ridx-- {
+	}
+	if idx := r.RIndex(eq); idx != ridx {
+		t.Fatalf("RIndex of %d is %d, expected %d", x, idx, ridx)
+	}
+}
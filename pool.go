@@ -0,0 +1,123 @@
+package ring
+
+import "sync"
+
+// Pool is a bounded pool of reusable items, such as large buffers, stored in a
+// Ring. Unlike sync.Pool, a Pool never holds more than its capacity, and does
+// not release items to the garbage collector on its own. A Pool is safe for
+// concurrent use.
+//
+// By default, Get returns the item most recently given to Put, so that items
+// that are still in cache are reused first. Set FIFO to return the least
+// recently used item instead.
+//
+// The zero value of Pool is an empty Pool, with no New function, that holds at
+// most 16 items. Use NewPool to create a Pool with a different capacity.
+type Pool[T any] struct {
+	// New constructs an item when Get is called on an empty Pool. If nil, Get
+	// returns the zero value of T when the Pool is empty.
+	New func() T
+	// Finalize, if not nil, is called with each item that Put drops because
+	// the Pool is full.
+	Finalize func(T)
+	// FIFO makes Get return the item that has been in the Pool longest.
+	FIFO bool
+
+	mu    sync.Mutex
+	r     *Ring[T]
+	stats PoolStats
+}
+
+// PoolStats holds counts of Pool operations.
+type PoolStats struct {
+	// Hits is the number of calls to Get that returned an item from the Pool.
+	Hits uint64
+	// Misses is the number of calls to Get that found the Pool empty.
+	Misses uint64
+	// Drops is the number of items that Put dropped because the Pool was full.
+	Drops uint64
+}
+
+// NewPool creates a new Pool that holds at most capacity items, and constructs
+// items with newFn when empty. If capacity is not positive, the call panics.
+func NewPool[T any](capacity int, newFn func() T) *Pool[T] {
+	if capacity <= 0 {
+		panic("ring: pool capacity must be positive")
+	}
+	return &Pool[T]{
+		New: newFn,
+		r:   New[T](capacity),
+	}
+}
+
+// Get removes an item from the Pool and returns it. If the Pool is empty, Get
+// returns the result of calling New.
+func (p *Pool[T]) Get() T {
+	p.mu.Lock()
+	p.lazyInit()
+	if p.r.Len() == 0 {
+		p.stats.Misses++
+		p.mu.Unlock()
+		if p.New == nil {
+			var zero T
+			return zero
+		}
+		return p.New()
+	}
+	p.stats.Hits++
+	var item T
+	if p.FIFO {
+		item = p.r.PopFront()
+	} else {
+		item = p.r.PopBack()
+	}
+	p.mu.Unlock()
+	return item
+}
+
+// Put adds an item to the Pool. If the Pool is full, the item is dropped and
+// passed to Finalize, instead of replacing an item in the Pool.
+func (p *Pool[T]) Put(item T) {
+	p.mu.Lock()
+	p.lazyInit()
+	if p.r.Full() {
+		p.stats.Drops++
+		p.mu.Unlock()
+		if p.Finalize != nil {
+			p.Finalize(item)
+		}
+		return
+	}
+	p.r.PushBack(item)
+	p.mu.Unlock()
+}
+
+// Len returns the number of items in the Pool.
+func (p *Pool[T]) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.r.Len()
+}
+
+// Cap returns the maximum number of items the Pool holds.
+func (p *Pool[T]) Cap() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lazyInit()
+	return p.r.Cap()
+}
+
+// Stats returns counts of the hits, misses, and drops of the Pool.
+func (p *Pool[T]) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// lazyInit creates the Ring of a zero value Pool. It must be called with mu
+// held.
+func (p *Pool[T]) lazyInit() {
+	if p.r == nil {
+		p.r = New[T](defaultCapacity)
+	}
+}
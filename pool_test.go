@@ -0,0 +1,110 @@
+package ring
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	var created int
+	p := NewPool(2, func() *[]byte {
+		created++
+		b := make([]byte, 0, 64)
+		return &b
+	})
+	var finalized []*[]byte
+	p.Finalize = func(b *[]byte) {
+		finalized = append(finalized, b)
+	}
+	if p.Cap() != 2 || p.Len() != 0 {
+		t.Fatal("wrong capacity or length of new pool")
+	}
+
+	a, b, c := p.Get(), p.Get(), p.Get()
+	if created != 3 || a == b || b == c {
+		t.Fatal("expected 3 new items")
+	}
+	p.Put(a)
+	p.Put(b)
+	p.Put(c)
+	if p.Len() != 2 || len(finalized) != 1 || finalized[0] != c {
+		t.Fatal("expected item dropped and finalized when pool full")
+	}
+
+	// LIFO by default.
+	if p.Get() != b || p.Get() != a {
+		t.Fatal("expected items in LIFO order")
+	}
+	if s := p.Stats(); s != (PoolStats{Hits: 2, Misses: 3, Drops: 1}) {
+		t.Fatalf("wrong stats: %+v", s)
+	}
+}
+
+func TestPoolFIFO(t *testing.T) {
+	p := NewPool[int](3, nil)
+	p.FIFO = true
+	for i := 1; i <= 4; i++ {
+		p.Put(i)
+	}
+	for i := 1; i <= 3; i++ {
+		if x := p.Get(); x != i {
+			t.Fatalf("expected %d, got %d", i, x)
+		}
+	}
+	// No New function, so empty pool returns zero value.
+	if p.Get() != 0 {
+		t.Fatal("expected zero value from empty pool")
+	}
+	if s := p.Stats(); s != (PoolStats{Hits: 3, Misses: 1, Drops: 1}) {
+		t.Fatalf("wrong stats: %+v", s)
+	}
+
+	assertPanics(t, "should panic with zero capacity", func() {
+		NewPool[int](0, nil)
+	})
+}
+
+func TestPoolZeroValue(t *testing.T) {
+	var created int
+	p := &Pool[int]{New: func() int {
+		created++
+		return created
+	}}
+	if p.Len() != 0 || p.Cap() != defaultCapacity {
+		t.Fatal("wrong length or capacity of zero value pool")
+	}
+	for i := 0; i < defaultCapacity+1; i++ {
+		p.Put(i)
+	}
+	if p.Len() != defaultCapacity || p.Get() != defaultCapacity-1 {
+		t.Fatal("zero value pool did not hold default capacity")
+	}
+
+	var q Pool[string]
+	q.Put("a")
+	if q.Get() != "a" || q.Get() != "" {
+		t.Fatal("wrong items from zero value pool")
+	}
+	if s := q.Stats(); s != (PoolStats{Hits: 1, Misses: 1}) {
+		t.Fatalf("wrong stats: %+v", s)
+	}
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	p := NewPool(4, func() []byte { return make([]byte, 16) })
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				p.Put(p.Get())
+			}
+		}()
+	}
+	wg.Wait()
+	s := p.Stats()
+	if s.Hits+s.Misses != 8000 || s.Misses-s.Drops != uint64(p.Len()) {
+		t.Fatalf("inconsistent stats %+v with length %d", s, p.Len())
+	}
+}
@@ -0,0 +1,79 @@
+package ring
+
+// Queue is a first-in first-out queue. Elements are added with PushBack and
+// removed with PopFront. Front returns the next element to be removed without
+// removing it. PopFront and Front panic if the Queue is empty.
+//
+// Ring, ByteRing, and ShmRing implement Queue. DiskRing does not, since its
+// methods return errors from file I/O.
+type Queue[T any] interface {
+	Len() int
+	PushBack(elem T)
+	PopFront() T
+	Front() T
+}
+
+// Stack is a last-in first-out stack. Elements are added with PushBack and
+// removed with PopBack. Back returns the next element to be removed without
+// removing it. PopBack and Back panic if the Stack is empty.
+//
+// Ring and ByteRing implement Stack.
+type Stack[T any] interface {
+	Len() int
+	PushBack(elem T)
+	PopBack() T
+	Back() T
+}
+
+// Deque is a double-ended queue, where elements may be added and removed at
+// either end. A Deque is both a Queue and a Stack.
+//
+// Ring and ByteRing implement Deque.
+type Deque[T any] interface {
+	Len() int
+	PushBack(elem T)
+	PushFront(elem T)
+	PopFront() T
+	PopBack() T
+	Front() T
+	Back() T
+}
+
+var (
+	_ Deque[int]    = (*Ring[int])(nil)
+	_ Deque[[]byte] = (*ByteRing)(nil)
+)
+
+// AsQueue returns a Queue that uses d as its storage. The returned Queue only
+// has the methods of Queue, so it cannot be used to add or remove elements at
+// the other ends of d, even by type assertion.
+func AsQueue[T any](d Deque[T]) Queue[T] {
+	return fifo[T]{d: d}
+}
+
+// AsStack returns a Stack that uses d as its storage. The returned Stack only
+// has the methods of Stack, so it cannot be used to add or remove elements at
+// the front of d, even by type assertion.
+func AsStack[T any](d Deque[T]) Stack[T] {
+	return lifo[T]{d: d}
+}
+
+// fifo adapts a Deque to provide only Queue methods.
+type fifo[T any] struct {
+	d Deque[T]
+}
+
+func (q fifo[T]) Len() int        { return q.d.Len() }
+func (q fifo[T]) PushBack(elem T) { q.d.PushBack(elem) }
+func (q fifo[T]) PopFront() T     { return q.d.PopFront() }
+func (q fifo[T]) Front() T        { return q.d.Front() }
+
+// lifo adapts a Deque to provide only Stack methods.
+type lifo[T any] struct {
+	d Deque[T]
+}
+
+func (s lifo[T]) Len() int        { return s.d.Len() }
+func (s lifo[T]) PushBack(elem T) { s.d.PushBack(elem) }
+func (s lifo[T]) PopBack() T      { return s.d.PopBack() }
+func (s lifo[T]) Back() T         { return s.d.Back() }
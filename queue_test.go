@@ -0,0 +1,102 @@
+package ring
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testQueue checks FIFO order of any Queue with room for at least 3 elements.
+func testQueue[T comparable](t *testing.T, q Queue[T], items ...T) {
+	t.Helper()
+	for _, x := range items {
+		q.PushBack(x)
+	}
+	if q.Len() != len(items) || q.Front() != items[0] {
+		t.Fatal("wrong length or front of queue")
+	}
+	for _, x := range items {
+		if y := q.PopFront(); y != x {
+			t.Fatalf("expected %v from queue, got %v", x, y)
+		}
+	}
+	assertPanics(t, "should panic when queue is empty", func() {
+		q.PopFront()
+	})
+}
+
+// testStack checks LIFO order of any Stack with room for at least 3 elements.
+func testStack[T comparable](t *testing.T, s Stack[T], items ...T) {
+	t.Helper()
+	for _, x := range items {
+		s.PushBack(x)
+	}
+	if s.Len() != len(items) || s.Back() != items[len(items)-1] {
+		t.Fatal("wrong length or back of stack")
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		if y := s.PopBack(); y != items[i] {
+			t.Fatalf("expected %v from stack, got %v", items[i], y)
+		}
+	}
+	assertPanics(t, "should panic when stack is empty", func() {
+		s.PopBack()
+	})
+}
+
+func TestQueue(t *testing.T) {
+	testQueue[int](t, New[int](4), 1, 2, 3)
+	testQueue(t, AsQueue[int](New[int](4)), 1, 2, 3)
+
+	b := NewByteRing(64)
+	q := AsQueue[[]byte](b)
+	q.PushBack([]byte("a"))
+	q.PushBack([]byte("b"))
+	if string(q.PopFront()) != "a" || string(b.Front()) != "b" {
+		t.Fatal("wrong records from byte ring queue")
+	}
+
+	if _, ok := q.(Deque[[]byte]); ok {
+		t.Fatal("queue adapter should not be a deque")
+	}
+	if _, ok := q.(Stack[[]byte]); ok {
+		t.Fatal("queue adapter should not be a stack")
+	}
+}
+
+func TestStack(t *testing.T) {
+	testStack[string](t, New[string](4), "a", "b", "c")
+	testStack(t, AsStack[string](New[string](4)), "a", "b", "c")
+
+	r := New[int](3)
+	s := AsStack[int](r)
+	for i := 0; i < 4; i++ {
+		s.PushBack(i)
+	}
+	// Pushing onto a full stack overwrites the bottom of the stack.
+	if s.Len() != 3 || r.Front() != 1 || s.Back() != 3 {
+		t.Fatal("wrong elements after overwrite:", r)
+	}
+
+	if _, ok := s.(Queue[int]); ok {
+		t.Fatal("stack adapter should not be a queue")
+	}
+}
+
+func TestDeque(t *testing.T) {
+	deques := []Deque[int]{New[int](8), &Ring[int]{}}
+	for i, d := range deques {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			d.PushBack(2)
+			d.PushFront(1)
+			d.PushBack(3)
+			if d.Len() != 3 || d.Front() != 1 || d.Back() != 3 {
+				t.Fatal("wrong length, front, or back of deque")
+			}
+			if d.PopFront() != 1 || d.PopBack() != 3 || d.PopBack() != 2 {
+				t.Fatal("wrong elements removed from deque")
+			}
+			testQueue(t, AsQueue(d), 4, 5, 6)
+			testStack(t, AsStack(d), 7, 8, 9)
+		})
+	}
+}
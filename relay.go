@@ -0,0 +1,78 @@
+package ring
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Relay forwards values from an input channel to an output channel, buffering
+// them in a Ring so that the sender never waits for a slow receiver. When the
+// Ring is full, each value received overwrites the oldest buffered value, so
+// the receiver gets the most recent values.
+type Relay[T any] struct {
+	dropped atomic.Uint64
+	done    chan struct{}
+}
+
+// NewRelay starts a goroutine that receives values from in, buffers up to
+// capacity of them in a Ring, and sends them to out, in the order received,
+// whenever out is ready.
+//
+// When in is closed, the Relay sends all remaining buffered values to out and
+// then closes out. When ctx is canceled, the Relay closes out immediately and
+// discards any buffered values. The Relay is done after closing out.
+//
+// If capacity is not positive, the call panics.
+func NewRelay[T any](ctx context.Context, in <-chan T, out chan<- T, capacity int) *Relay[T] {
+	if capacity <= 0 {
+		panic("ring: relay capacity must be positive")
+	}
+	rl := &Relay[T]{
+		done: make(chan struct{}),
+	}
+	go rl.run(ctx, in, out, New[T](capacity))
+	return rl
+}
+
+// Dropped returns the number of values received that were not sent, either
+// because they were overwritten or because they were discarded when ctx was
+// canceled.
+func (rl *Relay[T]) Dropped() uint64 {
+	return rl.dropped.Load()
+}
+
+// Done returns a channel that is closed when the Relay is done.
+func (rl *Relay[T]) Done() <-chan struct{} {
+	return rl.done
+}
+
+func (rl *Relay[T]) run(ctx context.Context, in <-chan T, out chan<- T, r *Ring[T]) {
+	defer close(rl.done)
+	defer close(out)
+
+	for in != nil || r.Len() != 0 {
+		// Only enable the send case when there is something to send.
+		var send chan<- T
+		var next T
+		if r.Len() != 0 {
+			send = out
+			next = r.Front()
+		}
+		select {
+		case <-ctx.Done():
+			rl.dropped.Add(uint64(r.Len()))
+			return
+		case x, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			if r.Full() {
+				rl.dropped.Add(1)
+			}
+			r.PushBack(x)
+		case send <- next:
+			r.PopFront()
+		}
+	}
+}
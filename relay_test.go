@@ -0,0 +1,95 @@
+package ring
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRelay(t *testing.T) {
+	in := make(chan int)
+	out := make(chan int)
+	rl := NewRelay(context.Background(), in, out, 4)
+
+	// Nothing receives from out, so the relay keeps the last 4 values.
+	for i := 0; i < 10; i++ {
+		in <- i
+	}
+	close(in)
+
+	var got []int
+	for x := range out {
+		got = append(got, x)
+	}
+	<-rl.Done()
+	if len(got) != 4 || got[0] != 6 || got[3] != 9 {
+		t.Fatal("expected last 4 values, got", got)
+	}
+	if rl.Dropped() != 6 {
+		t.Fatal("expected 6 values dropped, got", rl.Dropped())
+	}
+}
+
+func TestRelayOrder(t *testing.T) {
+	in := make(chan int)
+	out := make(chan int)
+	rl := NewRelay(context.Background(), in, out, 16)
+
+	go func() {
+		for i := 0; i < 1000; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+	// Values may be dropped, but those received are in order, and the last
+	// value is never dropped.
+	var n, last int
+	for x := range out {
+		if n != 0 && x <= last {
+			t.Fatalf("received %d after %d", x, last)
+		}
+		last = x
+		n++
+	}
+	if last != 999 {
+		t.Fatal("expected last value 999, got", last)
+	}
+	if uint64(n)+rl.Dropped() != 1000 {
+		t.Fatalf("received %d and dropped %d, expected total 1000", n, rl.Dropped())
+	}
+}
+
+func TestRelayCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := make(chan int)
+	rl := NewRelay(ctx, in, out, 8)
+
+	for i := 0; i < 3; i++ {
+		in <- i
+	}
+	cancel()
+
+	select {
+	case <-rl.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay not done after cancel")
+	}
+	if _, ok := <-out; ok {
+		t.Fatal("expected out to be closed")
+	}
+	if rl.Dropped() != 3 {
+		t.Fatal("expected 3 buffered values dropped, got", rl.Dropped())
+	}
+}
+
+func TestNewRelayPanics(t *testing.T) {
+	in := make(chan int)
+	out := make(chan int)
+	assertPanics(t, "should panic with zero capacity", func() {
+		NewRelay(context.Background(), in, out, 0)
+	})
+	assertPanics(t, "should panic with negative capacity", func() {
+		NewRelay(context.Background(), in, out, -1)
+	})
+}
@@ -1,17 +1,34 @@
 package ring
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// defaultCapacity is the capacity of the buffer allocated when an element is
+// added to a Ring that has zero capacity.
+const defaultCapacity = 16
 
 // Ring is a fixed-size circular buffer of items of the type sepcified by the
 // type argument. Pushing an item onto a full Ring overwrites the item at the
 // other end of the ring.
+//
+// The zero value of Ring is an empty Ring ready to use. A Ring that has zero
+// capacity, such as the zero value, allocates a buffer with a capacity of 16
+// when an element is first added to it. Methods that do not modify a Ring may
+// be called on a nil *Ring, which behaves as an empty Ring with zero capacity.
 type Ring[T any] struct {
 	buf   []T
 	head  int
 	tail  int
 	count int
+	mods  uint // count of structural modifications, checked by Cursor
 }
 
+// New creates a new Ring with the specified capacity. If capacity is zero, a
+// buffer with the default capacity is allocated when the first element is
+// added.
 func New[T any](capacity int) *Ring[T] {
 	return &Ring[T]{
 		buf: make([]T, capacity),
@@ -35,14 +52,23 @@ func (r *Ring[T]) Len() int {
 	return r.count
 }
 
+// Full reports whether the Ring is full, so that adding an element overwrites
+// the element at the other end of the Ring. A Ring with zero capacity is not
+// full, since adding an element allocates a buffer. If r is nil, r.Full() is
+// false.
 func (r *Ring[T]) Full() bool {
-	return r.count == len(r.buf)
+	return r.Cap() != 0 && r.count == len(r.buf)
 }
 
 // PushBack appends an element to the back of the Ring. Implements FIFO when
 // elements are removed with PopFront(), and LIFO when elements are removed
 // with PopBack. Wraps by overwriting front when Ring is full.
 func (r *Ring[T]) PushBack(elem T) {
+	if debug {
+		defer r.debugCheck()
+	}
+	r.lazyInit()
+	r.mods++
 	r.buf[r.tail] = elem
 	r.tail = r.next(r.tail)
 
@@ -58,6 +84,11 @@ func (r *Ring[T]) PushBack(elem T) {
 // elements are removed with PopBack(), and LIFO when elements are removed with
 // PopFront. Wraps by overwriting back when Ring is full.
 func (r *Ring[T]) PushFront(elem T) {
+	if debug {
+		defer r.debugCheck()
+	}
+	r.lazyInit()
+	r.mods++
 	// Calculate new head position.
 	r.head = r.prev(r.head)
 	r.buf[r.head] = elem
@@ -74,9 +105,13 @@ func (r *Ring[T]) PushFront(elem T) {
 // Implements FIFO when used with PushBack(). If the Ring is empty, the call
 // panics.
 func (r *Ring[T]) PopFront() T {
-	if r.count <= 0 {
+	if debug {
+		defer r.debugCheck()
+	}
+	if r.Len() <= 0 {
 		panic("PopFront called when empty")
 	}
+	r.mods++
 	ret := r.buf[r.head]
 	var zero T
 	r.buf[r.head] = zero
@@ -90,9 +125,13 @@ func (r *Ring[T]) PopFront() T {
 // Implements LIFO when used with PushBack(). If the Ring is empty, the call
 // panics.
 func (r *Ring[T]) PopBack() T {
-	if r.count <= 0 {
+	if debug {
+		defer r.debugCheck()
+	}
+	if r.Len() <= 0 {
 		panic("PopBack called when empty")
 	}
+	r.mods++
 	// Calculate new tail position
 	r.tail = r.prev(r.tail)
 
@@ -107,7 +146,7 @@ func (r *Ring[T]) PopBack() T {
 // Front returns the element at the front of the Ring. This is the element that
 // would be returned by PopFront(). This call panics if the Ring is empty.
 func (r *Ring[T]) Front() T {
-	if r.count <= 0 {
+	if r.Len() <= 0 {
 		panic("Front called when empty")
 	}
 	return r.buf[r.head]
@@ -116,7 +155,7 @@ func (r *Ring[T]) Front() T {
 // Back returns the element at the back of the Ring. This is the element that
 // would be returned by PopBack(). This call panics if the Ring is empty.
 func (r *Ring[T]) Back() T {
-	if r.count <= 0 {
+	if r.Len() <= 0 {
 		panic("Back called when empty")
 	}
 
@@ -142,22 +181,77 @@ func (r *Ring[T]) At(i int) T {
 	return r.buf[(r.head+i)%len(r.buf)]
 }
 
+// AtFrom returns the element at index i in the Ring, where a negative index
+// counts back from the end of the Ring. AtFrom(-1) is the same as Back() and
+// AtFrom(-Len()) is the same as Front(). Non-negative indexes are the same as
+// for At. If the index is invalid, the call panics.
+func (r *Ring[T]) AtFrom(i int) T {
+	return r.At(r.fromBack(i))
+}
+
 // Set assigns the item to index i in the Ring. Set indexes the Ring the same
 // as At but perform the opposite operation. If the index is invalid, the call
 // panics.
 func (r *Ring[T]) Set(i int, item T) {
+	if debug {
+		defer r.debugCheck()
+	}
 	if i < 0 || i >= r.Len() {
 		panic(outOfRangeText(i, r.Len()))
 	}
 	r.buf[(r.head+i)%len(r.buf)] = item
 }
 
+// Swap swaps the elements at indexes i and j. If either index is invalid, the
+// call panics.
+func (r *Ring[T]) Swap(i, j int) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if i < 0 || i >= r.Len() {
+		panic(outOfRangeText(i, r.Len()))
+	}
+	if j < 0 || j >= r.Len() {
+		panic(outOfRangeText(j, r.Len()))
+	}
+	l := len(r.buf)
+	i = (r.head + i) % l
+	j = (r.head + j) % l
+	r.buf[i], r.buf[j] = r.buf[j], r.buf[i]
+}
+
+// Reverse reverses the order of the elements in the Ring, so that the front
+// element becomes the back element. The elements are reversed in place.
+func (r *Ring[T]) Reverse() {
+	if debug {
+		defer r.debugCheck()
+	}
+	if r.Len() <= 1 {
+		return
+	}
+	r.mods++
+	l := len(r.buf)
+	for i, j := 0, r.count-1; i < j; i, j = i+1, j-1 {
+		a := (r.head + i) % l
+		b := (r.head + j) % l
+		r.buf[a], r.buf[b] = r.buf[b], r.buf[a]
+	}
+}
+
 // Rotate rotates the Ring n steps front-to-back. If n is negative, rotates
 // back-to-front. Having Ring provide Rotate() allows a more efficient
-// implementation, than only Pop and Push methods. that operates by only moving
-// the head and tail of the Ring. If Len() is one or less, or Ring is nil, then
-// Rotate does nothing.
+// implementation than only Pop and Push methods. If Len() is one or less, or
+// Ring is nil, then Rotate does nothing.
+//
+// Rotate is done in whichever direction moves fewer elements. When the Ring
+// is full, rotation only moves the head and tail of the Ring, and is O(1).
+// Otherwise, the elements rotated are moved across the empty part of the
+// buffer using block copies, and complexity is linear in the lesser of |n|
+// and Len()-|n|, however little of the buffer is empty.
 func (r *Ring[T]) Rotate(n int) {
+	if debug {
+		defer r.debugCheck()
+	}
 	if r.Len() <= 1 {
 		return
 	}
@@ -166,6 +260,14 @@ func (r *Ring[T]) Rotate(n int) {
 	if n == 0 {
 		return
 	}
+	r.mods++
+
+	// Rotate in the direction that moves fewer elements.
+	if n > r.count/2 {
+		n -= r.count
+	} else if n < -r.count/2 {
+		n += r.count
+	}
 
 	l := len(r.buf)
 
@@ -177,30 +279,25 @@ func (r *Ring[T]) Rotate(n int) {
 		return
 	}
 
-	var zero T
-
-	if n < 0 {
-		// Rotate back to front.
-		for ; n < 0; n++ {
-			// Calculate new head and tail.
-			r.head = (r.head - 1 + l) % l
-			r.tail = (r.tail - 1 + l) % l
-			// Put tail value at head and remove value at tail.
-			r.buf[r.head] = r.buf[r.tail]
-			r.buf[r.tail] = zero
-		}
+	// Move the block of elements being rotated across the empty space. The
+	// elements left in place become the new front or back, and only the
+	// slots that are not overwritten by the moved block are cleared.
+	free := l - r.count
+	if n > 0 {
+		// Rotate front to back: move elements from the front to the back.
+		r.shift(r.head, n, -free)
+		k := min(n, free)
+		r.clearSlots((r.head+n-k)%l, k)
+		r.head = (r.head + n) % l
+		r.tail = (r.tail + n) % l
 		return
 	}
-
-	// Rotate front to back.
-	for ; n > 0; n-- {
-		// Put head value at tail and remove value at head.
-		r.buf[r.tail] = r.buf[r.head]
-		r.buf[r.head] = zero
-		// Calculate new head and tail.
-		r.head = (r.head + 1) % l
-		r.tail = (r.tail + 1) % l
-	}
+	// Rotate back to front: move elements from the back to the front.
+	n = -n
+	r.tail = (r.tail - n + l) % l
+	r.head = (r.head - n + l) % l
+	r.shift(r.tail, n, free)
+	r.clearSlots(r.tail, min(n, free))
 }
 
 // Index returns the index into the Ring of the first item satisfying f(item),
@@ -222,7 +319,7 @@ func (r *Ring[T]) Index(f func(T) bool) int {
 // returned by Front().
 func (r *Ring[T]) RIndex(f func(T) bool) int {
 	if r.Len() > 0 {
-		l := r.Len()
+		l := len(r.buf)
 		for i := r.count - 1; i >= 0; i-- {
 			if f(r.buf[(r.head+i)%l]) {
 				return i
@@ -232,6 +329,34 @@ func (r *Ring[T]) RIndex(f func(T) bool) int {
 	return -1
 }
 
+// Range calls f for each element in the Ring, from front to back, until f
+// returns false. The Ring must not be modified by f.
+func (r *Ring[T]) Range(f func(item T) bool) {
+	if r.Len() == 0 {
+		return
+	}
+	l := len(r.buf)
+	for i := 0; i < r.count; i++ {
+		if !f(r.buf[(r.head+i)%l]) {
+			return
+		}
+	}
+}
+
+// RangeReverse calls f for each element in the Ring, from back to front, until
+// f returns false. The Ring must not be modified by f.
+func (r *Ring[T]) RangeReverse(f func(item T) bool) {
+	if r.Len() == 0 {
+		return
+	}
+	l := len(r.buf)
+	for i := r.count - 1; i >= 0; i-- {
+		if !f(r.buf[(r.head+i)%l]) {
+			return
+		}
+	}
+}
+
 // Insert is used to insert an element into the middle of the Ring, before the
 // element at the specified index. Insert(0,e) is the same as PushFront(e) and
 // Insert(Len(),e) is the same as PushBack(e). Accepts only non-negative index
@@ -242,7 +367,10 @@ func (r *Ring[T]) RIndex(f func(T) bool) int {
 // constant plus linear in the lesser of the distances between the index and
 // either of the ends of the Ring.
 func (r *Ring[T]) Insert(at int, item T) {
-	if at < 0 || at > r.count {
+	if debug {
+		defer r.debugCheck()
+	}
+	if at < 0 || at > r.Len() {
 		panic(outOfRangeText(at, r.Len()))
 	}
 	if r.Full() {
@@ -278,6 +406,9 @@ func (r *Ring[T]) Insert(at int, item T) {
 // constant plus linear in the lesser of the distances between the index and
 // either of the ends of the Ring.
 func (r *Ring[T]) Remove(at int) T {
+	if debug {
+		defer r.debugCheck()
+	}
 	if at < 0 || at >= r.Len() {
 		panic(outOfRangeText(at, r.Len()))
 	}
@@ -300,9 +431,300 @@ func (r *Ring[T]) Remove(at int) T {
 	return r.PopBack()
 }
 
+// InsertSlice inserts items into the Ring, before the element at the specified
+// index. InsertSlice(0,items...) places the items at the front of the Ring,
+// and InsertSlice(Len(),items...) places them at the back. Accepts only
+// non-negative index values, and panics if index is out of range or if there
+// is not enough free space in the Ring to hold the items.
+//
+// The elements on the shorter side of the index are moved using block copies.
+// Complexity of this function is linear in the number of items inserted plus
+// the lesser of the distances between the index and either of the ends of the
+// Ring.
+func (r *Ring[T]) InsertSlice(at int, items ...T) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if at < 0 || at > r.Len() {
+		panic(outOfRangeText(at, r.Len()))
+	}
+	n := len(items)
+	if n == 0 {
+		return
+	}
+	if n > r.initCap()-r.Len() {
+		panic("not enough space in ring to insert items")
+	}
+	r.lazyInit()
+	r.mods++
+	l := len(r.buf)
+	if at*2 < r.count {
+		// Move the elements before the index toward the front.
+		r.head = (r.head - n + l) % l
+		r.shift((r.head+n)%l, at, -n)
+	} else {
+		// Move the elements from the index toward the back.
+		r.shift((r.head+at)%l, r.count-at, n)
+		r.tail = (r.tail + n) % l
+	}
+	r.copyIn((r.head+at)%l, items)
+	r.count += n
+}
+
+// RemoveRange removes the elements with indexes from, up to but not including
+// to, from the Ring. Accepts only non-negative index values, and panics if
+// either index is out of range or if from is greater than to.
+//
+// The elements on the shorter side of the removed range are moved using block
+// copies. Complexity of this function is linear in the number of elements
+// removed plus the lesser of the distances between the range and either of
+// the ends of the Ring.
+func (r *Ring[T]) RemoveRange(from, to int) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if from < 0 || from > r.Len() {
+		panic(outOfRangeText(from, r.Len()))
+	}
+	if to < from || to > r.Len() {
+		panic(outOfRangeText(to, r.Len()))
+	}
+	n := to - from
+	if n == 0 {
+		return
+	}
+	r.mods++
+	l := len(r.buf)
+	if from < r.count-to {
+		// Move the elements before the range toward the back.
+		r.shift(r.head, from, n)
+		r.clearSlots(r.head, n)
+		r.head = (r.head + n) % l
+	} else {
+		// Move the elements after the range toward the front.
+		r.shift((r.head+to)%l, r.count-to, -n)
+		r.tail = (r.tail - n + l) % l
+		r.clearSlots(r.tail, n)
+	}
+	r.count -= n
+}
+
+// Splice replaces the elements with indexes from, up to but not including to,
+// with items. The number of items may differ from the number of elements
+// replaced. Accepts only non-negative index values, and panics if either index
+// is out of range, if from is greater than to, or if there is not enough free
+// space in the Ring to hold the items.
+//
+// Complexity of this function is the same as InsertSlice or RemoveRange for
+// the difference between the number of items and the number of elements
+// replaced.
+func (r *Ring[T]) Splice(from, to int, items ...T) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if from < 0 || from > r.Len() {
+		panic(outOfRangeText(from, r.Len()))
+	}
+	if to < from || to > r.Len() {
+		panic(outOfRangeText(to, r.Len()))
+	}
+	if len(items) == 0 {
+		r.RemoveRange(from, to)
+		return
+	}
+	if r.Len()-(to-from)+len(items) > r.initCap() {
+		panic("not enough space in ring to insert items")
+	}
+	r.lazyInit()
+	// Overwrite as many elements as possible in place.
+	m := min(to-from, len(items))
+	if m != 0 {
+		r.copyIn((r.head+from)%len(r.buf), items[:m])
+	}
+	if len(items) > m {
+		r.InsertSlice(from+m, items[m:]...)
+	} else {
+		r.RemoveRange(from+m, to)
+	}
+}
+
+// RemoveFunc removes all elements for which f returns true, and returns the
+// number of elements removed. The remaining elements keep their order.
+//
+// The Ring is compacted in a single pass, so complexity is linear in the
+// length of the Ring, regardless of the number of elements removed.
+func (r *Ring[T]) RemoveFunc(f func(T) bool) int {
+	if debug {
+		defer r.debugCheck()
+	}
+	if r.Len() == 0 {
+		return 0
+	}
+	l := len(r.buf)
+	w := 0
+	for i := 0; i < r.count; i++ {
+		item := r.buf[(r.head+i)%l]
+		if f(item) {
+			continue
+		}
+		if w != i {
+			r.buf[(r.head+w)%l] = item
+		}
+		w++
+	}
+
+	// Remove values from vacated slots.
+	var zero T
+	for i := w; i < r.count; i++ {
+		r.buf[(r.head+i)%l] = zero
+	}
+	removed := r.count - w
+	if removed != 0 {
+		r.mods++
+	}
+	r.count = w
+	r.tail = (r.head + w) % l
+	return removed
+}
+
+// Retain removes all elements for which f returns false, and returns the
+// number of elements removed. The remaining elements keep their order.
+// Complexity is the same as RemoveFunc.
+func (r *Ring[T]) Retain(f func(T) bool) int {
+	return r.RemoveFunc(func(item T) bool {
+		return !f(item)
+	})
+}
+
+// TrimFront removes n elements from the front of the Ring. Accepts only
+// non-negative values, and panics if n is greater than Len(). Complexity is
+// linear in n.
+func (r *Ring[T]) TrimFront(n int) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if n < 0 || n > r.Len() {
+		panic(outOfRangeText(n, r.Len()))
+	}
+	if n == 0 {
+		return
+	}
+	r.mods++
+	r.clearSlots(r.head, n)
+	r.head = (r.head + n) % len(r.buf)
+	r.count -= n
+}
+
+// TrimBack removes n elements from the back of the Ring. Accepts only
+// non-negative values, and panics if n is greater than Len(). Complexity is
+// linear in n.
+func (r *Ring[T]) TrimBack(n int) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if n < 0 || n > r.Len() {
+		panic(outOfRangeText(n, r.Len()))
+	}
+	if n == 0 {
+		return
+	}
+	r.mods++
+	r.tail = (r.tail - n + len(r.buf)) % len(r.buf)
+	r.clearSlots(r.tail, n)
+	r.count -= n
+}
+
+// TruncateTo removes elements from the front of the Ring until no more than n
+// elements remain. When elements are added with PushBack, this keeps the
+// newest n elements. If n is negative, the call panics.
+func (r *Ring[T]) TruncateTo(n int) {
+	if n < 0 {
+		panic(outOfRangeText(n, r.Len()))
+	}
+	if n < r.Len() {
+		r.TrimFront(r.count - n)
+	}
+}
+
+// Drain removes n elements from the front of the Ring and returns them in a
+// newly allocated slice, in front-to-back order. Accepts only non-negative
+// values, and panics if n is greater than Len().
+func (r *Ring[T]) Drain(n int) []T {
+	if n < 0 || n > r.Len() {
+		panic(outOfRangeText(n, r.Len()))
+	}
+	items := make([]T, n)
+	r.CopyTo(items)
+	r.TrimFront(n)
+	return items
+}
+
+// Clone returns a copy of the Ring with the same capacity and elements. If r
+// is nil, Clone returns nil.
+func (r *Ring[T]) Clone() *Ring[T] {
+	if r == nil {
+		return nil
+	}
+	return &Ring[T]{
+		buf:   slices.Clone(r.buf),
+		head:  r.head,
+		tail:  r.tail,
+		count: r.count,
+	}
+}
+
+// CopyTo copies elements of the Ring, in front-to-back order, into dst. It
+// returns the number of elements copied, which is the minimum of len(dst) and
+// Len().
+func (r *Ring[T]) CopyTo(dst []T) int {
+	n := min(len(dst), r.Len())
+	if n == 0 {
+		return 0
+	}
+	c := copy(dst[:n], r.buf[r.head:])
+	copy(dst[c:n], r.buf)
+	return n
+}
+
+// Slice returns a newly allocated slice containing the elements from index i
+// up to, but not including, index j, in front-to-back order. The indexes must
+// satisfy 0 <= i <= j <= Len(), otherwise the call panics.
+func (r *Ring[T]) Slice(i, j int) []T {
+	if i < 0 || i > r.Len() {
+		panic(outOfRangeText(i, r.Len()))
+	}
+	if j < i || j > r.Len() {
+		panic(outOfRangeText(j, r.Len()))
+	}
+	items := make([]T, j-i)
+	if len(items) == 0 {
+		return items
+	}
+	p := (r.head + i) % len(r.buf)
+	c := copy(items, r.buf[p:])
+	copy(items[c:], r.buf)
+	return items
+}
+
+// SliceRange is the same as Slice, except that a negative index counts back
+// from the end of the Ring, as with AtFrom. For example, SliceRange(-5, Len())
+// returns the last 5 elements and SliceRange(2, -1) returns all but the first
+// two and the last element. Unlike slicing in some other languages, indexes
+// are not clamped to the length of the Ring, and an index that is out of range
+// after counting back from the end causes a panic.
+func (r *Ring[T]) SliceRange(i, j int) []T {
+	return r.Slice(r.fromBack(i), r.fromBack(j))
+}
+
 // Reset resets the Ring to be empty, but it retains the underlying storage for
-// use by future writes.
+// use by future writes. If Ring is nil, then Reset does nothing.
 func (r *Ring[T]) Reset() {
+	if r == nil {
+		return
+	}
+	if debug {
+		defer r.debugCheck()
+	}
 	var zero T
 	l := len(r.buf)
 	h := r.head
@@ -312,6 +734,98 @@ func (r *Ring[T]) Reset() {
 	r.head = 0
 	r.tail = 0
 	r.count = 0
+	r.mods++
+}
+
+// initCap returns the capacity the Ring has after lazyInit, without
+// allocating.
+func (r *Ring[T]) initCap() int {
+	if r.Cap() == 0 {
+		return defaultCapacity
+	}
+	return len(r.buf)
+}
+
+// lazyInit allocates a buffer with the default capacity if the Ring has zero
+// capacity.
+func (r *Ring[T]) lazyInit() {
+	if len(r.buf) == 0 {
+		r.buf = make([]T, defaultCapacity)
+		r.head = 0
+		r.tail = 0
+	}
+}
+
+// linearize moves the elements of the Ring so that they are contiguous in the
+// underlying buffer, and returns the slice of the buffer holding them in
+// front-to-back order. The elements are moved only if they wrap around the end
+// of the buffer.
+func (r *Ring[T]) linearize() []T {
+	if r.head+r.count <= len(r.buf) {
+		return r.buf[r.head : r.head+r.count]
+	}
+	// Rotate the buffer left by head, which keeps empty slots after the back.
+	slices.Reverse(r.buf[:r.head])
+	slices.Reverse(r.buf[r.head:])
+	slices.Reverse(r.buf)
+	r.head = 0
+	r.tail = r.count % len(r.buf)
+	return r.buf[:r.count]
+}
+
+// shift moves n elements, starting at buffer position src, delta positions
+// toward the back of the buffer, or toward the front if delta is negative.
+// Elements are moved using block copies, wrapping around the buffer.
+func (r *Ring[T]) shift(src, n, delta int) {
+	l := len(r.buf)
+	if delta > 0 {
+		// Copy from the back so that elements are copied before being
+		// overwritten. Positions s and d are the ends of the next blocks.
+		s := (src+n-1)%l + 1
+		d := (src+delta+n-1)%l + 1
+		for n > 0 {
+			k := min(n, s, d)
+			copy(r.buf[d-k:d], r.buf[s-k:s])
+			n -= k
+			s = (s-k-1+l)%l + 1
+			d = (d-k-1+l)%l + 1
+		}
+		return
+	}
+	s := src
+	d := (src + delta + l) % l
+	for n > 0 {
+		k := min(n, l-s, l-d)
+		copy(r.buf[d:d+k], r.buf[s:s+k])
+		n -= k
+		s = (s + k) % l
+		d = (d + k) % l
+	}
+}
+
+// copyIn copies items into the buffer starting at buffer position p, wrapping
+// around the buffer.
+func (r *Ring[T]) copyIn(p int, items []T) {
+	n := copy(r.buf[p:], items)
+	copy(r.buf, items[n:])
+}
+
+// clearSlots sets n slots, starting at buffer position p, to the zero value,
+// wrapping around the buffer.
+func (r *Ring[T]) clearSlots(p, n int) {
+	end := min(p+n, len(r.buf))
+	clear(r.buf[p:end])
+	clear(r.buf[:n-(end-p)])
+}
+
+// fromBack converts a negative index, counting back from the end of the Ring,
+// to a non-negative index. Other indexes are returned unchanged, so that they
+// are reported as they were given if out of range.
+func (r *Ring[T]) fromBack(i int) int {
+	if i < 0 && i >= -r.Len() {
+		return i + r.Len()
+	}
+	return i
 }
 
 // prev returns the previous buffer position wrapping around buffer.
@@ -325,30 +839,128 @@ func (r *Ring[T]) next(i int) int {
 	return (i + 1) % len(r.buf)
 }
 
+// ResizePolicy determines which elements are kept when a Ring is resized to a
+// capacity smaller than its length. Elements added with PushBack are oldest at
+// the front of the Ring and newest at the back.
+type ResizePolicy int
+
+const (
+	// KeepOldest keeps the elements at the front of the Ring, and evicts
+	// elements from the back.
+	KeepOldest ResizePolicy = iota
+	// KeepNewest keeps the elements at the back of the Ring, and evicts
+	// elements from the front.
+	KeepNewest
+)
+
 // Resize resizes the Ring to have the specified capacity. Any items present in
-// the Ring are copied into the resized ring.
+// the Ring are copied into the resized ring. If the new capacity is less than
+// Len(), then the items at the front of the Ring are kept. If newSize is
+// negative, the call panics.
 func (r *Ring[T]) Resize(newSize int) {
-	if r.count == newSize {
+	r.ResizeFunc(newSize, KeepOldest, nil)
+}
+
+// ResizeFunc resizes the Ring to have the specified capacity. If the new
+// capacity is less than Len(), then policy determines which items are kept,
+// and, if evicted is not nil, it is called with each of the other items in
+// front-to-back order. If newSize is negative, the call panics.
+func (r *Ring[T]) ResizeFunc(newSize int, policy ResizePolicy, evicted func(T)) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if newSize < 0 {
+		panic(fmt.Sprintf("ring: invalid capacity %d", newSize))
+	}
+	if newSize == r.Cap() {
 		return
 	}
 
-	newBuf := make([]T, newSize)
-	var n int
-	if r.tail > r.head {
-		n = copy(newBuf, r.buf[r.head:r.tail])
-	} else {
-		n = copy(newBuf, r.buf[r.head:])
-		if n < len(r.buf) {
-			n += copy(newBuf[n:], r.buf[:r.tail])
+	// Determine which items to keep.
+	from, to := 0, r.count
+	if r.count > newSize {
+		if policy == KeepNewest {
+			from = r.count - newSize
+		} else {
+			to = newSize
+		}
+		if evicted != nil {
+			l := len(r.buf)
+			for i := 0; i < from; i++ {
+				evicted(r.buf[(r.head+i)%l])
+			}
+			for i := to; i < r.count; i++ {
+				evicted(r.buf[(r.head+i)%l])
+			}
 		}
 	}
 
-	r.count = n
+	newBuf := make([]T, newSize)
+	if to > from {
+		p := (r.head + from) % len(r.buf)
+		n := copy(newBuf[:to-from], r.buf[p:])
+		copy(newBuf[n:to-from], r.buf)
+	}
+
+	r.mods++
+	r.count = to - from
 	r.head = 0
-	r.tail = n
+	r.tail = 0
+	if newSize != 0 {
+		r.tail = r.count % newSize
+	}
 	r.buf = newBuf
 }
 
+// Validate checks the internal consistency of the Ring, and returns an error
+// describing the first problem found, or nil if the Ring is valid. It checks
+// that the head, tail, and length are consistent with each other and with the
+// capacity, and that every slot of the buffer not holding an element is set
+// to the zero value. If Ring is nil, then nil is returned.
+//
+// Validate is intended for testing and debugging. Building with the ringdebug
+// tag calls Validate after every method that modifies a Ring, and panics if
+// it returns an error.
+func (r *Ring[T]) Validate() error {
+	if r == nil {
+		return nil
+	}
+	l := len(r.buf)
+	if l == 0 {
+		if r.head != 0 || r.tail != 0 || r.count != 0 {
+			return fmt.Errorf("ring: head %d, tail %d, length %d with zero capacity", r.head, r.tail, r.count)
+		}
+		return nil
+	}
+	if r.head < 0 || r.head >= l {
+		return fmt.Errorf("ring: head %d out of range with capacity %d", r.head, l)
+	}
+	if r.tail < 0 || r.tail >= l {
+		return fmt.Errorf("ring: tail %d out of range with capacity %d", r.tail, l)
+	}
+	if r.count < 0 || r.count > l {
+		return fmt.Errorf("ring: length %d out of range with capacity %d", r.count, l)
+	}
+	if (r.head+r.count)%l != r.tail {
+		return fmt.Errorf("ring: tail %d inconsistent with head %d and length %d", r.tail, r.head, r.count)
+	}
+	for i := r.count; i < l; i++ {
+		p := (r.head + i) % l
+		if !reflect.ValueOf(&r.buf[p]).Elem().IsZero() {
+			return fmt.Errorf("ring: empty slot %d holds non-zero value", p)
+		}
+	}
+	return nil
+}
+
+// debugCheck panics if the Ring is not valid. It is called after modifying a
+// Ring when built with the ringdebug tag.
+func (r *Ring[T]) debugCheck() {
+	if err := r.Validate(); err != nil {
+		panic(err)
+	}
+}
+
 func outOfRangeText(i, len int) string {
 	return fmt.Sprintf("ring: index out of range %d with length %d", i, len)
 }
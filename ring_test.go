@@ -1,7 +1,10 @@
 package ring
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"testing"
 	"unicode"
 )
@@ -52,6 +55,150 @@ func TestRingNil(t *testing.T) {
 	}
 }
 
+// ringMethods calls each method of Ring, with arguments valid for an empty
+// Ring if possible, and reports which methods panic.
+var ringMethods = []struct {
+	name   string
+	modify bool // method adds elements
+	empty  bool // method panics when ring is empty
+	call   func(r *Ring[int])
+}{
+	{"Len", false, false, func(r *Ring[int]) { r.Len() }},
+	{"Cap", false, false, func(r *Ring[int]) { r.Cap() }},
+	{"Full", false, false, func(r *Ring[int]) { r.Full() }},
+	{"Front", false, true, func(r *Ring[int]) { r.Front() }},
+	{"Back", false, true, func(r *Ring[int]) { r.Back() }},
+	{"At", false, true, func(r *Ring[int]) { r.At(0) }},
+	{"AtFrom", false, true, func(r *Ring[int]) { r.AtFrom(-1) }},
+	{"Slice", false, false, func(r *Ring[int]) { r.Slice(0, 0) }},
+	{"SliceRange", false, false, func(r *Ring[int]) { r.SliceRange(0, 0) }},
+	{"Set", false, true, func(r *Ring[int]) { r.Set(0, 1) }},
+	{"Swap", false, true, func(r *Ring[int]) { r.Swap(0, 0) }},
+	{"PopFront", false, true, func(r *Ring[int]) { r.PopFront() }},
+	{"PopBack", false, true, func(r *Ring[int]) { r.PopBack() }},
+	{"Remove", false, true, func(r *Ring[int]) { r.Remove(0) }},
+	{"Index", false, false, func(r *Ring[int]) { r.Index(func(int) bool { return true }) }},
+	{"RIndex", false, false, func(r *Ring[int]) { r.RIndex(func(int) bool { return true }) }},
+	{"Range", false, false, func(r *Ring[int]) { r.Range(func(int) bool { return true }) }},
+	{"RangeReverse", false, false, func(r *Ring[int]) { r.RangeReverse(func(int) bool { return true }) }},
+	{"Reverse", false, false, func(r *Ring[int]) { r.Reverse() }},
+	{"Rotate", false, false, func(r *Ring[int]) { r.Rotate(3) }},
+	{"RemoveRange", false, false, func(r *Ring[int]) { r.RemoveRange(0, 0) }},
+	{"RemoveFunc", false, false, func(r *Ring[int]) { r.RemoveFunc(func(int) bool { return true }) }},
+	{"Retain", false, false, func(r *Ring[int]) { r.Retain(func(int) bool { return true }) }},
+	{"TrimFront", false, false, func(r *Ring[int]) { r.TrimFront(0) }},
+	{"TrimBack", false, false, func(r *Ring[int]) { r.TrimBack(0) }},
+	{"TruncateTo", false, false, func(r *Ring[int]) { r.TruncateTo(0) }},
+	{"Drain", false, false, func(r *Ring[int]) { r.Drain(0) }},
+	{"Clone", false, false, func(r *Ring[int]) { r.Clone() }},
+	{"CopyTo", false, false, func(r *Ring[int]) { r.CopyTo(make([]int, 1)) }},
+	{"Reset", false, false, func(r *Ring[int]) { r.Reset() }},
+	{"Validate", false, false, func(r *Ring[int]) { r.Validate() }},
+	{"SortFunc", false, false, func(r *Ring[int]) { r.SortFunc(cmp.Compare[int]) }},
+	{"SortStableFunc", false, false, func(r *Ring[int]) { r.SortStableFunc(cmp.Compare[int]) }},
+	{"IsSortedFunc", false, false, func(r *Ring[int]) { r.IsSortedFunc(cmp.Compare[int]) }},
+	{"BinarySearchFunc", false, false, func(r *Ring[int]) { BinarySearchFunc(r, 1, cmp.Compare[int]) }},
+	{"Equal", false, false, func(r *Ring[int]) { Equal(r, r) }},
+	{"Compare", false, false, func(r *Ring[int]) { Compare(r, r) }},
+	{"Contains", false, false, func(r *Ring[int]) { Contains(r, 1) }},
+	{"MarshalJSON", false, false, func(r *Ring[int]) { json.Marshal(r) }},
+	{"MarshalBinary", false, false, func(r *Ring[int]) { r.MarshalBinary() }},
+	{"String", false, false, func(r *Ring[int]) { _ = r.String() }},
+	{"GoString", false, false, func(r *Ring[int]) { _ = r.GoString() }},
+	{"Format", false, false, func(r *Ring[int]) { _ = fmt.Sprintf("%v %+v %#v %d", r, r, r, r) }},
+	{"Cursor", false, true, func(r *Ring[int]) { r.Cursor(0) }},
+	{"View", false, false, func(r *Ring[int]) {
+		v := r.View()
+		v.Len()
+		v.Cap()
+		v.Full()
+		v.Index(func(int) bool { return true })
+		v.RIndex(func(int) bool { return true })
+		v.Range(func(int) bool { return true })
+		v.RangeReverse(func(int) bool { return true })
+		v.Slice(0, 0)
+		v.SliceRange(0, 0)
+		v.CopyTo(make([]int, 1))
+		_ = v.String()
+	}},
+	{"ViewFront", false, true, func(r *Ring[int]) { r.View().Front() }},
+	{"ViewAt", false, true, func(r *Ring[int]) { r.View().At(0) }},
+	{"Resize", false, false, func(r *Ring[int]) { r.Resize(0) }},
+	{"PushBack", true, false, func(r *Ring[int]) { r.PushBack(1) }},
+	{"PushFront", true, false, func(r *Ring[int]) { r.PushFront(1) }},
+	{"Insert", true, false, func(r *Ring[int]) { r.Insert(0, 1) }},
+	{"InsertSlice", true, false, func(r *Ring[int]) { r.InsertSlice(0, 1, 2) }},
+	{"Splice", true, false, func(r *Ring[int]) { r.Splice(0, 0, 1, 2) }},
+}
+
+func TestNilRingMethods(t *testing.T) {
+	for _, m := range ringMethods {
+		var r *Ring[int]
+		// Nil ring cannot be modified, and behaves as empty otherwise.
+		expect := m.modify || m.empty
+		if didPanic(func() { m.call(r) }) != expect {
+			t.Errorf("%s on nil ring: expected panic %v", m.name, expect)
+		}
+	}
+}
+
+func TestZeroValueRing(t *testing.T) {
+	for _, m := range ringMethods {
+		var r Ring[int]
+		if didPanic(func() { m.call(&r) }) != m.empty {
+			t.Errorf("%s on zero value ring: expected panic %v", m.name, m.empty)
+		}
+		if err := r.Validate(); err != nil {
+			t.Errorf("%s on zero value ring: %s", m.name, err)
+		}
+		if m.modify {
+			if r.Cap() != defaultCapacity || r.Len() == 0 {
+				t.Errorf("%s on zero value ring: expected elements and default capacity", m.name)
+			}
+		} else if r.Cap() != 0 || r.Len() != 0 {
+			t.Errorf("%s on zero value ring: expected empty ring with zero capacity", m.name)
+		}
+	}
+
+	var r Ring[string]
+	if r.Full() {
+		t.Fatal("zero value ring should not be full")
+	}
+	for i := 0; i < defaultCapacity+1; i++ {
+		r.PushBack(fmt.Sprint(i))
+	}
+	if r.Len() != defaultCapacity || !r.Full() || r.Front() != "1" {
+		t.Fatal("zero value ring did not wrap at default capacity")
+	}
+
+	// Resizing to zero capacity makes ring allocate again on next write.
+	r.Resize(0)
+	if r.Full() {
+		t.Fatal("zero capacity ring should not be full")
+	}
+	r.PushFront("x")
+	if r.Cap() != defaultCapacity || r.Front() != "x" {
+		t.Fatal("zero capacity ring did not allocate on write")
+	}
+
+	// Inserting more than the default capacity panics without allocating.
+	var z Ring[int]
+	big := make([]int, defaultCapacity+1)
+	assertPanics(t, "should panic when inserting more than default capacity", func() {
+		z.InsertSlice(0, big...)
+	})
+	assertPanics(t, "should panic when splicing more than default capacity", func() {
+		z.Splice(0, 0, big...)
+	})
+	if z.Cap() != 0 {
+		t.Fatal("failed insert allocated buffer for zero value ring")
+	}
+	z.Splice(0, 0, big[1:]...)
+	if z.Cap() != defaultCapacity || !z.Full() {
+		t.Fatal("expected splice to fill zero value ring")
+	}
+}
+
 func TestCycleForward(t *testing.T) {
 	r := New[rune](5)
 	for _, char := range "hello" {
@@ -346,6 +493,26 @@ func TestRotate(t *testing.T) {
 	}
 }
 
+// TestRotateModel compares Rotate against rotating a slice, at every wrap
+// offset, length, and rotation distance.
+func TestRotateModel(t *testing.T) {
+	const size = 9
+	for offset := 0; offset < size; offset++ {
+		for length := 0; length <= size; length++ {
+			for n := -2 * size; n <= 2*size; n++ {
+				r, model := offsetRing(size, offset, length)
+				r.Rotate(n)
+				if length != 0 {
+					k := ((n % length) + length) % length
+					model = append(model[k:], model[:k]...)
+				}
+				checkModel(t, r, model)
+				checkZeroed(t, r)
+			}
+		}
+	}
+}
+
 func TestAt(t *testing.T) {
 	r := New[int](10)
 
@@ -412,6 +579,105 @@ func TestReset(t *testing.T) {
 	}
 }
 
+func TestResize(t *testing.T) {
+	r := New[int](8)
+	for i := 0; i < 12; i++ {
+		r.PushBack(i)
+	}
+	r.PopBack()
+	r.PopBack()
+	// ring: 4 5 6 7 8 9
+	r.Resize(6)
+	if r.Cap() != 6 || r.Len() != 6 || !r.Full() {
+		t.Fatal("expected full ring with capacity 6")
+	}
+	r.PushBack(10)
+	for i, x := range []int{5, 6, 7, 8, 9, 10} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+
+	r.Resize(10)
+	if r.Cap() != 10 || r.Len() != 6 {
+		t.Fatal("expected capacity 10 and length 6 after growing")
+	}
+	r.PushBack(11)
+	r.PushFront(4)
+	if r.Front() != 4 || r.Back() != 11 || r.Len() != 8 {
+		t.Fatal("ring not usable after growing")
+	}
+
+	r.Resize(3)
+	for i, x := range []int{4, 5, 6} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+
+	// Resizing an empty ring, with head not at start of buffer, keeps it empty.
+	r.TrimFront(2)
+	r.PopFront()
+	r.Resize(5)
+	if r.Cap() != 5 || r.Len() != 0 {
+		t.Fatal("expected empty ring with capacity 5")
+	}
+
+	r.Resize(0)
+	if r.Cap() != 0 || r.Len() != 0 {
+		t.Fatal("expected empty ring with capacity 0")
+	}
+	assertPanics(t, "should panic when resizing to negative capacity", func() {
+		r.Resize(-1)
+	})
+}
+
+func TestResizeFunc(t *testing.T) {
+	var evicted []int
+	onEvict := func(item int) {
+		evicted = append(evicted, item)
+	}
+
+	r := New[int](8)
+	for i := 0; i < 12; i++ {
+		r.PushBack(i)
+	}
+	// ring: 4 5 6 7 8 9 10 11
+	r.ResizeFunc(5, KeepNewest, onEvict)
+	if fmt.Sprint(evicted) != "[4 5 6]" {
+		t.Fatal("wrong items evicted:", evicted)
+	}
+	for i, x := range []int{7, 8, 9, 10, 11} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+
+	evicted = nil
+	r.ResizeFunc(2, KeepOldest, onEvict)
+	if fmt.Sprint(evicted) != "[9 10 11]" {
+		t.Fatal("wrong items evicted:", evicted)
+	}
+	if r.Front() != 7 || r.Back() != 8 {
+		t.Fatal("wrong items kept")
+	}
+
+	evicted = nil
+	r.ResizeFunc(4, KeepNewest, onEvict)
+	r.ResizeFunc(4, KeepNewest, onEvict)
+	if len(evicted) != 0 {
+		t.Fatal("expected no items evicted when growing")
+	}
+	if r.Cap() != 4 || r.Len() != 2 {
+		t.Fatal("wrong capacity or length after growing")
+	}
+
+	r.ResizeFunc(0, KeepNewest, onEvict)
+	if fmt.Sprint(evicted) != "[7 8]" {
+		t.Fatal("wrong items evicted:", evicted)
+	}
+}
+
 func TestIndex(t *testing.T) {
 	r := New[rune](16)
 	for _, x := range "Hello, 世界" {
@@ -466,6 +732,28 @@ func TestRIndex(t *testing.T) {
 	}
 }
 
+func TestRIndexWrap(t *testing.T) {
+	r := New[int](8)
+	for i := 0; i < 10; i++ {
+		r.PushBack(i)
+	}
+	r.PopBack()
+	r.PopBack()
+	// ring: 2 3 4 5 6 7
+	// buffer: [_,_,2,3,4,5,6,7]
+	r.PushBack(8)
+	// buffer: [8,_,2,3,4,5,6,7]
+	for i := 0; i < r.Len(); i++ {
+		x := r.At(i)
+		idx := r.RIndex(func(item int) bool {
+			return item == x
+		})
+		if idx != i {
+			t.Fatalf("expected index %d for %d, got %d", i, x, idx)
+		}
+	}
+}
+
 func TestInsert(t *testing.T) {
 	r := New[rune](16)
 	for _, x := range "ABCDEFG" {
@@ -598,6 +886,641 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestRemoveFunc(t *testing.T) {
+	r := New[int](10)
+	for i := 0; i < 16; i++ {
+		r.PushBack(i)
+	}
+	// ring: 6 7 8 9 10 11 12 13 14 15
+	// buffer: [10,11,12,13,14,15,6,7,8,9]
+	n := r.RemoveFunc(func(item int) bool {
+		return item%2 == 0
+	})
+	if n != 5 {
+		t.Fatal("expected 5 items removed, got", n)
+	}
+	if r.Len() != 5 {
+		t.Fatal("expected length 5, got", r.Len())
+	}
+	for i, x := range []int{7, 9, 11, 13, 15} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+
+	// Check that there are no remaining references in vacated slots.
+	for i := 0; i < len(r.buf)-r.Len(); i++ {
+		if r.buf[(r.tail+i)%len(r.buf)] != 0 {
+			t.Fatal("ring has non-zero removed elements after RemoveFunc()")
+		}
+	}
+
+	r.PushBack(17)
+	r.PushFront(5)
+	if r.Front() != 5 || r.Back() != 17 || r.Len() != 7 {
+		t.Fatal("ring not usable after RemoveFunc")
+	}
+
+	if r.RemoveFunc(func(item int) bool { return false }) != 0 {
+		t.Fatal("expected no items removed")
+	}
+	if r.RemoveFunc(func(item int) bool { return true }) != 7 || r.Len() != 0 {
+		t.Fatal("expected all items removed")
+	}
+
+	var nilRing *Ring[int]
+	if nilRing.RemoveFunc(func(item int) bool { return true }) != 0 {
+		t.Fatal("expected no items removed from nil ring")
+	}
+}
+
+func TestRetain(t *testing.T) {
+	r := New[string](8)
+	for _, s := range []string{"a", "bb", "c", "dd", "ee", "f", "g", "hh", "i", "jj"} {
+		r.PushBack(s)
+	}
+	n := r.Retain(func(item string) bool {
+		return len(item) == 2
+	})
+	if n != 4 {
+		t.Fatal("expected 4 items removed, got", n)
+	}
+	for i, x := range []string{"dd", "ee", "hh", "jj"} {
+		if r.At(i) != x {
+			t.Errorf("expected %s at index %d, got %s", x, i, r.At(i))
+		}
+	}
+	for i := 0; i < len(r.buf)-r.Len(); i++ {
+		if r.buf[(r.tail+i)%len(r.buf)] != "" {
+			t.Fatal("ring has non-zero removed elements after Retain()")
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	r := New[int](8)
+	for i := 0; i < 12; i++ {
+		r.PushBack(i)
+	}
+	r.PopFront()
+
+	c := r.Clone()
+	if c.Cap() != r.Cap() || c.Len() != r.Len() {
+		t.Fatal("clone has different capacity or length")
+	}
+	for i := 0; i < r.Len(); i++ {
+		if c.At(i) != r.At(i) {
+			t.Fatalf("expected %d at index %d, got %d", r.At(i), i, c.At(i))
+		}
+	}
+
+	// Modifying clone must not modify original.
+	c.Set(0, 100)
+	c.PushBack(100)
+	if r.Front() == 100 || r.Back() == 100 {
+		t.Fatal("modifying clone modified original")
+	}
+
+	var nilRing *Ring[int]
+	if nilRing.Clone() != nil {
+		t.Fatal("expected clone of nil ring to be nil")
+	}
+}
+
+func TestCopyTo(t *testing.T) {
+	r := New[int](8)
+	for i := 0; i < 12; i++ {
+		r.PushBack(i)
+	}
+	// ring: 4 5 6 7 8 9 10 11
+	// buffer: [8,9,10,11,4,5,6,7]
+	dst := make([]int, 10)
+	if n := r.CopyTo(dst); n != 8 {
+		t.Fatal("expected 8 items copied, got", n)
+	}
+	for i := 0; i < 8; i++ {
+		if dst[i] != i+4 {
+			t.Fatalf("expected %d at index %d, got %d", i+4, i, dst[i])
+		}
+	}
+
+	dst = make([]int, 3)
+	if n := r.CopyTo(dst); n != 3 {
+		t.Fatal("expected 3 items copied, got", n)
+	}
+	for i := 0; i < 3; i++ {
+		if dst[i] != i+4 {
+			t.Fatalf("expected %d at index %d, got %d", i+4, i, dst[i])
+		}
+	}
+
+	var nilRing *Ring[int]
+	if nilRing.CopyTo(dst) != 0 {
+		t.Fatal("expected nothing copied from nil ring")
+	}
+}
+
+func TestRange(t *testing.T) {
+	r, model := offsetRing(8, 5, 7)
+	var fwd, rev []int
+	r.Range(func(item int) bool {
+		fwd = append(fwd, item)
+		return true
+	})
+	r.RangeReverse(func(item int) bool {
+		rev = append(rev, item)
+		return true
+	})
+	if !slices.Equal(fwd, model) {
+		t.Fatalf("Range visited %v, expected %v", fwd, model)
+	}
+	slices.Reverse(rev)
+	if !slices.Equal(rev, model) {
+		t.Fatal("RangeReverse did not visit elements in reverse order")
+	}
+
+	var n int
+	r.Range(func(int) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatal("Range did not stop when f returned false")
+	}
+	n = 0
+	r.RangeReverse(func(int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Fatal("RangeReverse did not stop when f returned false")
+	}
+}
+
+func TestAtFrom(t *testing.T) {
+	r, model := offsetRing(8, 6, 5)
+	for i := -len(model); i < len(model); i++ {
+		expect := model[(i+len(model))%len(model)]
+		if r.AtFrom(i) != expect {
+			t.Errorf("expected %d at index %d, got %d", expect, i, r.AtFrom(i))
+		}
+	}
+	if r.AtFrom(-1) != r.Back() || r.AtFrom(-r.Len()) != r.Front() {
+		t.Fatal("AtFrom does not match Front and Back")
+	}
+	assertPanics(t, "should panic with index before front", func() {
+		r.AtFrom(-6)
+	})
+	assertPanics(t, "should panic with index after back", func() {
+		r.AtFrom(5)
+	})
+}
+
+func TestSlice(t *testing.T) {
+	for offset := 0; offset < 8; offset++ {
+		r, model := offsetRing(8, offset, 7)
+		for i := 0; i <= len(model); i++ {
+			for j := i; j <= len(model); j++ {
+				if s := r.Slice(i, j); !slices.Equal(s, model[i:j]) {
+					t.Fatalf("offset %d: Slice(%d, %d) is %v, expected %v", offset, i, j, s, model[i:j])
+				}
+			}
+		}
+	}
+
+	r, model := offsetRing(8, 3, 6)
+	s := r.Slice(1, 4)
+	s[0] = 100
+	if r.At(1) != model[1] {
+		t.Fatal("modifying slice modified ring")
+	}
+	if s := r.Slice(6, 6); s == nil || len(s) != 0 {
+		t.Fatal("expected empty non-nil slice")
+	}
+
+	assertPanics(t, "should panic with negative index", func() {
+		r.Slice(-1, 2)
+	})
+	assertPanics(t, "should panic with index past end", func() {
+		r.Slice(2, 7)
+	})
+	assertPanics(t, "should panic with reversed indexes", func() {
+		r.Slice(3, 2)
+	})
+}
+
+func TestSliceRange(t *testing.T) {
+	r, model := offsetRing(8, 5, 7)
+	n := len(model)
+	tests := []struct {
+		i, j   int
+		expect []int
+	}{
+		{0, n, model},
+		{-5, n, model[n-5:]},
+		{2, -1, model[2 : n-1]},
+		{-3, -1, model[n-3 : n-1]},
+		{-n, -n, model[:0]},
+		{-1, n, model[n-1:]},
+	}
+	for _, tc := range tests {
+		if s := r.SliceRange(tc.i, tc.j); !slices.Equal(s, tc.expect) {
+			t.Errorf("SliceRange(%d, %d) is %v, expected %v", tc.i, tc.j, s, tc.expect)
+		}
+	}
+
+	assertPanics(t, "should panic with index before front", func() {
+		r.SliceRange(-8, -1)
+	})
+	assertPanics(t, "should panic with index past end", func() {
+		r.SliceRange(0, 8)
+	})
+	assertPanics(t, "should panic with reversed indexes", func() {
+		r.SliceRange(-1, -2)
+	})
+}
+
+func TestSwap(t *testing.T) {
+	r := New[rune](5)
+	for _, x := range "ABCDEFG" {
+		r.PushBack(x)
+	}
+	// ring: C D E F G
+	// buffer: [F,G,C,D,E]
+	r.Swap(0, 4)
+	r.Swap(1, 2)
+	for i, x := range "GEDFC" {
+		if r.At(i) != x {
+			t.Errorf("expected %c at index %d, got %c", x, i, r.At(i))
+		}
+	}
+	r.Swap(3, 3)
+	if r.At(3) != 'F' {
+		t.Error("swapping index with itself changed element")
+	}
+
+	assertPanics(t, "should panic when swapping negative index", func() {
+		r.Swap(-1, 2)
+	})
+	assertPanics(t, "should panic when swapping index out of range", func() {
+		r.Swap(2, 5)
+	})
+}
+
+func TestReverse(t *testing.T) {
+	for n := 0; n <= 8; n++ {
+		r := New[int](8)
+		for i := 0; i < 5+n; i++ {
+			r.PushBack(0)
+			r.PopFront()
+		}
+		for i := 0; i < n; i++ {
+			r.PushBack(i)
+		}
+		r.Reverse()
+		if r.Len() != n {
+			t.Fatal("reverse changed length")
+		}
+		for i := 0; i < n; i++ {
+			if r.At(i) != n-1-i {
+				t.Fatalf("expected %d at index %d, got %d", n-1-i, i, r.At(i))
+			}
+		}
+	}
+
+	var nilRing *Ring[int]
+	nilRing.Reverse()
+}
+
+func TestInsertSlice(t *testing.T) {
+	r := New[rune](16)
+	for _, x := range "ABCDEFG" {
+		r.PushBack(x)
+	}
+	r.InsertSlice(5, 'x', 'y', 'z') // ABCDExyzFG
+	r.InsertSlice(1, '1', '2')      // A12BCDExyzFG
+	r.InsertSlice(0, '<')           // <A12BCDExyzFG
+	r.InsertSlice(r.Len(), '>')     // <A12BCDExyzFG>
+	r.InsertSlice(3)                // no change
+	for i, x := range "<A12BCDExyzFG>" {
+		if r.At(i) != x {
+			t.Errorf("expected %c at index %d, got %c", x, i, r.At(i))
+		}
+	}
+
+	assertPanics(t, "should panic when inserting more items than free space", func() {
+		r.InsertSlice(2, 'a', 'b', 'c')
+	})
+	assertPanics(t, "should panic when inserting at negative index", func() {
+		r.InsertSlice(-1, 'a')
+	})
+	assertPanics(t, "should panic when inserting out of range", func() {
+		r.InsertSlice(r.Len()+1, 'a')
+	})
+}
+
+func TestRemoveRange(t *testing.T) {
+	r := New[rune](16)
+	for _, x := range "ABCDEFGHIJ" {
+		r.PushBack(x)
+	}
+	r.RemoveRange(6, 8) // ABCDEFIJ
+	r.RemoveRange(1, 3) // ADEFIJ
+	r.RemoveRange(2, 2) // no change
+	for i, x := range "ADEFIJ" {
+		if r.At(i) != x {
+			t.Errorf("expected %c at index %d, got %c", x, i, r.At(i))
+		}
+	}
+	checkZeroed(t, r)
+
+	r.RemoveRange(0, r.Len())
+	if r.Len() != 0 {
+		t.Fatal("expected empty ring")
+	}
+	checkZeroed(t, r)
+
+	assertPanics(t, "should panic when removing from negative index", func() {
+		r.RemoveRange(-1, 0)
+	})
+	assertPanics(t, "should panic when removing past end", func() {
+		r.RemoveRange(0, 1)
+	})
+	r.PushBack('A')
+	r.PushBack('B')
+	assertPanics(t, "should panic when from greater than to", func() {
+		r.RemoveRange(2, 1)
+	})
+}
+
+func TestSplice(t *testing.T) {
+	r := New[rune](12)
+	for _, x := range "ABCDEFGH" {
+		r.PushBack(x)
+	}
+	r.Splice(2, 4, 'x', 'y', 'z') // ABxyzEFGH
+	r.Splice(6, 8, '1')           // ABxyzE1H
+	r.Splice(0, 1, '<', '-')      // <-BxyzE1H
+	for i, x := range "<-BxyzE1H" {
+		if r.At(i) != x {
+			t.Errorf("expected %c at index %d, got %c", x, i, r.At(i))
+		}
+	}
+	checkZeroed(t, r)
+
+	assertPanics(t, "should panic when not enough space", func() {
+		r.Splice(0, 1, 'a', 'b', 'c', 'd', 'e')
+	})
+	assertPanics(t, "should panic when from greater than to", func() {
+		r.Splice(3, 2, 'a')
+	})
+}
+
+// TestBulkInsertRemove compares InsertSlice, RemoveRange and Splice against
+// the same operations on a slice, at every position and wrap offset.
+func TestBulkInsertRemove(t *testing.T) {
+	const size = 10
+	for offset := 0; offset < size; offset++ {
+		for length := 0; length <= size; length++ {
+			for at := 0; at <= length; at++ {
+				for n := 0; n <= size-length; n++ {
+					r, model := offsetRing(size, offset, length)
+					items := make([]int, n)
+					for i := range items {
+						items[i] = 100 + i
+					}
+					r.InsertSlice(at, items...)
+					model = slices.Insert(model, at, items...)
+					checkModel(t, r, model)
+				}
+				for to := at; to <= length; to++ {
+					r, model := offsetRing(size, offset, length)
+					r.RemoveRange(at, to)
+					model = slices.Delete(model, at, to)
+					checkModel(t, r, model)
+					checkZeroed(t, r)
+
+					for n := 0; n <= size-length+to-at; n++ {
+						r, model = offsetRing(size, offset, length)
+						items := make([]int, n)
+						for i := range items {
+							items[i] = 100 + i
+						}
+						r.Splice(at, to, items...)
+						model = slices.Replace(model, at, to, items...)
+						checkModel(t, r, model)
+						checkZeroed(t, r)
+					}
+				}
+			}
+		}
+	}
+}
+
+// offsetRing returns a Ring holding 1 through length, with the front at buffer
+// position offset, and a slice holding the same elements.
+func offsetRing(size, offset, length int) (*Ring[int], []int) {
+	r := New[int](size)
+	for i := 0; i < offset; i++ {
+		r.PushBack(0)
+		r.PopFront()
+	}
+	model := make([]int, length)
+	for i := range model {
+		model[i] = i + 1
+		r.PushBack(i + 1)
+	}
+	return r, model
+}
+
+func checkModel(t *testing.T, r *Ring[int], model []int) {
+	t.Helper()
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != len(model) {
+		t.Fatalf("expected length %d, got %d", len(model), r.Len())
+	}
+	for i, x := range model {
+		if r.At(i) != x {
+			t.Fatalf("expected %v, got %v", model, r.items())
+		}
+	}
+}
+
+// checkZeroed checks that there are no remaining references in slots that do
+// not hold elements.
+func checkZeroed[T comparable](t *testing.T, r *Ring[T]) {
+	t.Helper()
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	var zero T
+	for i := 0; i < len(r.buf)-r.Len(); i++ {
+		if r.buf[(r.tail+i)%len(r.buf)] != zero {
+			t.Fatal("ring has non-zero value in empty slot")
+		}
+	}
+}
+
+func TestTrim(t *testing.T) {
+	r := New[int](8)
+	for i := 0; i < 12; i++ {
+		r.PushBack(i)
+	}
+	// ring: 4 5 6 7 8 9 10 11
+	// buffer: [8,9,10,11,4,5,6,7]
+	r.TrimFront(5)
+	if r.Len() != 3 || r.Front() != 9 {
+		t.Fatal("wrong length or front after TrimFront")
+	}
+	checkZeroed(t, r)
+	r.TrimBack(2)
+	if r.Len() != 1 || r.Front() != 9 || r.Back() != 9 {
+		t.Fatal("wrong length or back after TrimBack")
+	}
+	checkZeroed(t, r)
+
+	for i := 0; i < 8; i++ {
+		r.PushFront(i)
+	}
+	r.TrimBack(7)
+	for i, x := range []int{7} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+	checkZeroed(t, r)
+
+	r.TrimFront(0)
+	r.TrimBack(0)
+	r.TrimFront(1)
+	if r.Len() != 0 {
+		t.Fatal("expected empty ring")
+	}
+	checkZeroed(t, r)
+
+	assertPanics(t, "should panic when trimming more than length", func() {
+		r.TrimFront(1)
+	})
+	assertPanics(t, "should panic when trimming more than length", func() {
+		r.TrimBack(1)
+	})
+	assertPanics(t, "should panic when trimming negative count", func() {
+		r.TrimFront(-1)
+	})
+}
+
+func TestTruncateTo(t *testing.T) {
+	r := New[int](8)
+	for i := 0; i < 12; i++ {
+		r.PushBack(i)
+	}
+	r.TruncateTo(10)
+	if r.Len() != 8 {
+		t.Fatal("truncating to more than length changed length")
+	}
+	r.TruncateTo(3)
+	for i, x := range []int{9, 10, 11} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+	checkZeroed(t, r)
+	r.TruncateTo(0)
+	if r.Len() != 0 {
+		t.Fatal("expected empty ring")
+	}
+	assertPanics(t, "should panic when truncating to negative length", func() {
+		r.TruncateTo(-1)
+	})
+}
+
+func TestDrain(t *testing.T) {
+	r := New[string](4)
+	for _, s := range []string{"a", "b", "c", "d", "e", "f"} {
+		r.PushBack(s)
+	}
+	items := r.Drain(3)
+	if fmt.Sprint(items) != "[c d e]" {
+		t.Fatal("wrong items drained:", items)
+	}
+	if r.Len() != 1 || r.Front() != "f" {
+		t.Fatal("wrong items remaining after drain")
+	}
+	checkZeroed(t, r)
+	if len(r.Drain(0)) != 0 {
+		t.Fatal("expected no items drained")
+	}
+	assertPanics(t, "should panic when draining more than length", func() {
+		r.Drain(2)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	r := New[*int](4)
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		x := i
+		r.PushBack(&x)
+	}
+	r.PopFront()
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.Clone()
+	c.head = 4
+	if c.Validate() == nil {
+		t.Error("expected error for head out of range")
+	}
+	c = r.Clone()
+	c.tail = -1
+	if c.Validate() == nil {
+		t.Error("expected error for tail out of range")
+	}
+	c = r.Clone()
+	c.count = 5
+	if c.Validate() == nil {
+		t.Error("expected error for length out of range")
+	}
+	c = r.Clone()
+	c.count--
+	if c.Validate() == nil {
+		t.Error("expected error for inconsistent tail")
+	}
+	c = r.Clone()
+	c.buf[c.tail] = new(int)
+	if c.Validate() == nil {
+		t.Error("expected error for non-zero empty slot")
+	}
+
+	var nilRing *Ring[int]
+	if nilRing.Validate() != nil {
+		t.Error("expected nil ring to be valid")
+	}
+	empty := New[int](0)
+	if empty.Validate() != nil {
+		t.Error("expected zero capacity ring to be valid")
+	}
+	empty.count = 1
+	if empty.Validate() == nil {
+		t.Error("expected error for length with zero capacity")
+	}
+}
+
+func TestDebugCheck(t *testing.T) {
+	r := New[int](4)
+	r.PushBack(1)
+	r.debugCheck()
+	r.tail = 3
+	assertPanics(t, "should panic when ring is not valid", func() {
+		r.debugCheck()
+	})
+}
+
 func TestFrontBackOutOfRangePanics(t *testing.T) {
 	const msg = "should panic when peeking empty ring"
 	r := New[rune](16)
@@ -727,3 +1650,38 @@ func assertPanics(t *testing.T, name string, f func()) {
 
 	f()
 }
+
+// BenchmarkRotate measures Rotate on partially filled, nearly full, and full
+// rings, compared with rotating by popping and pushing elements.
+func BenchmarkRotate(b *testing.B) {
+	for _, size := range []int{100, 10000} {
+		fills := []struct {
+			name string
+			len  int
+		}{
+			{"partial", size * 9 / 10},
+			{"nearlyfull", size - 1},
+			{"full", size},
+		}
+		for _, fill := range fills {
+			for _, n := range []int{1, size / 4, size * 3 / 4} {
+				r := New[int](size)
+				for i := 0; i < fill.len; i++ {
+					r.PushBack(i)
+				}
+				b.Run(fmt.Sprintf("size=%d/%s/n=%d", size, fill.name, n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						r.Rotate(n)
+					}
+				})
+				b.Run(fmt.Sprintf("size=%d/%s/n=%d/PopPush", size, fill.name, n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						for j := 0; j < n; j++ {
+							r.PushBack(r.PopFront())
+						}
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,220 @@
+//go:build linux
+
+package ring
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	shmMagic   = 0x4d485352 // "RSHM"
+	shmVersion = 1
+
+	// Header layout. The head and tail positions are on separate cache lines
+	// since each is written by a different process.
+	shmHeadOffset = 64
+	shmTailOffset = 128
+	shmDataOffset = 192
+)
+
+// ShmRing is a fixed-size circular buffer of fixed-size records in a
+// memory-mapped file, for passing records between a producer and a consumer
+// in different processes. Use a file in /dev/shm to avoid disk I/O.
+//
+// ShmRing is a single-producer single-consumer ring: one process, or
+// goroutine, may call PushBack while another calls PopFront and Front. As with
+// Ring, pushing a record onto a full ShmRing overwrites the record at the
+// front. This is done without locks, so the producer never waits for the
+// consumer.
+type ShmRing struct {
+	file    *os.File
+	mem     []byte
+	recSize int
+	slots   uint64
+	head    *uint64 // position of front record, advanced by consumer
+	tail    *uint64 // position after back record, advanced by producer
+}
+
+var _ Queue[[]byte] = (*ShmRing)(nil)
+
+// CreateShm creates a ShmRing in the file at path, replacing any existing
+// file. The file is sized to hold capacity records of recordSize bytes, after
+// a header that records the magic number, version, record size and capacity.
+func CreateShm(path string, recordSize, capacity int) (*ShmRing, error) {
+	if recordSize <= 0 {
+		return nil, fmt.Errorf("ring: invalid record size %d", recordSize)
+	}
+	if capacity <= 0 {
+		return nil, fmt.Errorf("ring: invalid capacity %d", capacity)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	size := shmDataOffset + int64(recordSize)*int64(capacity)
+	if err = f.Truncate(size); err != nil {
+		f.Close()
+		return nil, err
+	}
+	s, err := mapShm(f, int(size))
+	if err != nil {
+		return nil, err
+	}
+	binary.LittleEndian.PutUint64(s.mem[8:], uint64(recordSize))
+	binary.LittleEndian.PutUint64(s.mem[16:], uint64(capacity))
+	binary.LittleEndian.PutUint32(s.mem[4:], shmVersion)
+	atomic.StoreUint32((*uint32)(unsafe.Pointer(&s.mem[0])), shmMagic)
+	s.recSize = recordSize
+	s.slots = uint64(capacity)
+	return s, nil
+}
+
+// OpenShm opens a ShmRing previously created by CreateShm, possibly in
+// another process.
+func OpenShm(path string) (*ShmRing, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	size := fi.Size()
+	if size < shmDataOffset {
+		f.Close()
+		return nil, errors.New("ring: file is not a shared memory ring")
+	}
+	s, err := mapShm(f, int(size))
+	if err != nil {
+		return nil, err
+	}
+	if atomic.LoadUint32((*uint32)(unsafe.Pointer(&s.mem[0]))) != shmMagic {
+		s.Close()
+		return nil, errors.New("ring: file is not a shared memory ring")
+	}
+	if v := binary.LittleEndian.Uint32(s.mem[4:]); v != shmVersion {
+		s.Close()
+		return nil, fmt.Errorf("ring: unsupported shared memory ring version %d", v)
+	}
+	recSize := binary.LittleEndian.Uint64(s.mem[8:])
+	slots := binary.LittleEndian.Uint64(s.mem[16:])
+	if recSize == 0 || slots == 0 || uint64(size-shmDataOffset)/recSize < slots {
+		s.Close()
+		return nil, errors.New("ring: corrupt shared memory ring header")
+	}
+	s.recSize = int(recSize)
+	s.slots = slots
+	return s, nil
+}
+
+// mapShm maps the file into memory. The file is closed if mapping fails.
+func mapShm(f *os.File, size int) (*ShmRing, error) {
+	mem, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &ShmRing{
+		file: f,
+		mem:  mem,
+		head: (*uint64)(unsafe.Pointer(&mem[shmHeadOffset])),
+		tail: (*uint64)(unsafe.Pointer(&mem[shmTailOffset])),
+	}, nil
+}
+
+// Cap returns the maximum number of records the ShmRing can hold.
+func (s *ShmRing) Cap() int {
+	return int(s.slots)
+}
+
+// Len returns the number of records currently stored in the ShmRing.
+func (s *ShmRing) Len() int {
+	h := atomic.LoadUint64(s.head)
+	t := atomic.LoadUint64(s.tail)
+	// The producer may have advanced head and tail after head was loaded.
+	if t-h > s.slots {
+		return int(s.slots)
+	}
+	return int(t - h)
+}
+
+// RecordSize returns the size, in bytes, of each record.
+func (s *ShmRing) RecordSize() int {
+	return s.recSize
+}
+
+// PushBack appends a record to the back of the ShmRing. Wraps by overwriting
+// front when ShmRing is full. The record must be exactly RecordSize bytes,
+// otherwise the call panics. Only the producer may call PushBack.
+func (s *ShmRing) PushBack(rec []byte) {
+	if len(rec) != s.recSize {
+		panic(fmt.Sprintf("ring: record size %d, expected %d", len(rec), s.recSize))
+	}
+	t := atomic.LoadUint64(s.tail)
+	h := atomic.LoadUint64(s.head)
+	// If full, move head past the front record before overwriting it. If this
+	// fails, the consumer has just removed the front record.
+	if t-h == s.slots {
+		atomic.CompareAndSwapUint64(s.head, h, h+1)
+	}
+	copy(s.slot(t), rec)
+	atomic.StoreUint64(s.tail, t+1)
+}
+
+// PopFront removes and returns the record from the front of the ShmRing. If
+// the ShmRing is empty, the call panics. Only the consumer may call PopFront.
+func (s *ShmRing) PopFront() []byte {
+	rec := make([]byte, s.recSize)
+	for {
+		h := atomic.LoadUint64(s.head)
+		if h == atomic.LoadUint64(s.tail) {
+			panic("PopFront called when empty")
+		}
+		copy(rec, s.slot(h))
+		// If the producer moved head while copying, then the record may have
+		// been overwritten, so try again with the new front record.
+		if atomic.CompareAndSwapUint64(s.head, h, h+1) {
+			return rec
+		}
+	}
+}
+
+// Front returns the record at the front of the ShmRing. This call panics if
+// the ShmRing is empty. Only the consumer may call Front.
+func (s *ShmRing) Front() []byte {
+	rec := make([]byte, s.recSize)
+	for {
+		h := atomic.LoadUint64(s.head)
+		if h == atomic.LoadUint64(s.tail) {
+			panic("Front called when empty")
+		}
+		copy(rec, s.slot(h))
+		if atomic.LoadUint64(s.head) == h {
+			return rec
+		}
+	}
+}
+
+// Close unmaps the ShmRing and closes its file. The file is not removed.
+func (s *ShmRing) Close() error {
+	err := syscall.Munmap(s.mem)
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+	s.mem = nil
+	return err
+}
+
+// slot returns the memory holding the record at position pos.
+func (s *ShmRing) slot(pos uint64) []byte {
+	off := shmDataOffset + int(pos%s.slots)*s.recSize
+	return s.mem[off : off+s.recSize]
+}
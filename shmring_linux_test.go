@@ -0,0 +1,160 @@
+//go:build linux
+
+package ring
+
+import (
+	"encoding/binary"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const shmHelperEnv = "RING_SHM_HELPER_PATH"
+
+func shmRecord(i uint64) []byte {
+	rec := make([]byte, 16)
+	binary.LittleEndian.PutUint64(rec, i)
+	binary.LittleEndian.PutUint64(rec[8:], ^i)
+	return rec
+}
+
+func TestShmRing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shm")
+	s, err := CreateShm(path, 16, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.Cap() != 4 || s.Len() != 0 || s.RecordSize() != 16 {
+		t.Fatal("wrong capacity, length, or record size for new shared memory ring")
+	}
+
+	r, err := OpenShm(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if r.Cap() != 4 || r.RecordSize() != 16 {
+		t.Fatal("wrong capacity or record size for opened shared memory ring")
+	}
+
+	for i := uint64(0); i < 6; i++ {
+		s.PushBack(shmRecord(i))
+	}
+	if r.Len() != 4 {
+		t.Fatalf("expected length 4, got %d", r.Len())
+	}
+	if binary.LittleEndian.Uint64(r.Front()) != 2 {
+		t.Fatal("expected front record to be 2 after overwrite")
+	}
+	for i := uint64(2); i < 6; i++ {
+		rec := r.PopFront()
+		if binary.LittleEndian.Uint64(rec) != i {
+			t.Fatalf("expected record %d, got %d", i, binary.LittleEndian.Uint64(rec))
+		}
+	}
+	if s.Len() != 0 {
+		t.Fatal("expected empty ring")
+	}
+	assertPanics(t, "should panic when removing from empty ring", func() {
+		r.PopFront()
+	})
+	assertPanics(t, "should panic when peeking empty ring", func() {
+		r.Front()
+	})
+	assertPanics(t, "should panic when pushing wrong record size", func() {
+		s.PushBack([]byte("short"))
+	})
+}
+
+func TestShmRingOpenErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := CreateShm(filepath.Join(dir, "shm"), 0, 4); err == nil {
+		t.Error("expected error for invalid record size")
+	}
+	if _, err := CreateShm(filepath.Join(dir, "shm"), 8, 0); err == nil {
+		t.Error("expected error for invalid capacity")
+	}
+	if _, err := OpenShm(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing file")
+	}
+	other := filepath.Join(dir, "other")
+	if err := os.WriteFile(other, make([]byte, 4096), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenShm(other); err == nil {
+		t.Error("expected error for file that is not a shared memory ring")
+	}
+}
+
+func TestShmRingCrossProcess(t *testing.T) {
+	const count = 5000
+	path := filepath.Join(t.TempDir(), "shm")
+	s, err := CreateShm(path, 16, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestShmRingHelperProcess$")
+	cmd.Env = append(os.Environ(), shmHelperEnv+"="+path)
+	cmd.Stderr = os.Stderr
+	if err = cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for i := uint64(0); i < count; {
+		if s.Len() == 0 {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out after receiving %d records", i)
+			}
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		rec := s.PopFront()
+		if binary.LittleEndian.Uint64(rec) != i || binary.LittleEndian.Uint64(rec[8:]) != ^i {
+			t.Fatalf("expected record %d, got %v", i, rec)
+		}
+		i++
+	}
+	if err = cmd.Wait(); err != nil {
+		t.Fatal("helper process failed:", err)
+	}
+	if s.Len() != 0 {
+		t.Fatal("expected empty ring after receiving all records")
+	}
+}
+
+// TestShmRingHelperProcess is run in a separate process by
+// TestShmRingCrossProcess to produce records.
+func TestShmRingHelperProcess(t *testing.T) {
+	path := os.Getenv(shmHelperEnv)
+	if path == "" {
+		return
+	}
+	s, err := OpenShm(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	for i := uint64(0); i < uint64(s.Cap()); i++ {
+		s.PushBack(shmRecord(i))
+	}
+}
+
+func TestShmRingQueue(t *testing.T) {
+	s, err := CreateShm(filepath.Join(t.TempDir(), "shm"), 16, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	var q Queue[[]byte] = s
+	q.PushBack(shmRecord(1))
+	q.PushBack(shmRecord(2))
+	if q.Len() != 2 || binary.LittleEndian.Uint64(q.PopFront()) != 1 {
+		t.Fatal("wrong record from shared memory queue")
+	}
+}
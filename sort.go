@@ -0,0 +1,97 @@
+package ring
+
+import "slices"
+
+// Sorter adapts a Ring to sort.Interface, using LessFunc to compare elements,
+// so that a Ring can be passed to sort.Sort. Sorter also implements
+// heap.Interface, so that a Ring can be used with container/heap:
+//
+//	h := ring.Sorter[int]{Ring: ring.New[int](16), LessFunc: func(a, b int) bool { return a < b }}
+//	heap.Push(h, 3)
+//	smallest := heap.Pop(h).(int)
+type Sorter[T any] struct {
+	*Ring[T]
+	LessFunc func(a, b T) bool
+}
+
+// Less reports whether the element at index i is less than the element at
+// index j.
+func (s Sorter[T]) Less(i, j int) bool {
+	return s.LessFunc(s.At(i), s.At(j))
+}
+
+// Push appends x, which must be of type T, to the back of the Ring. To keep
+// container/heap from overwriting an element, Push panics if the Ring is full.
+func (s Sorter[T]) Push(x any) {
+	if s.Full() {
+		panic("cannot push onto full ring")
+	}
+	s.PushBack(x.(T))
+}
+
+// Pop removes and returns the element at the back of the Ring.
+func (s Sorter[T]) Pop() any {
+	return s.PopBack()
+}
+
+// SortFunc sorts the elements of the Ring in ascending order as determined by
+// the cmp function, as with slices.SortFunc. The elements are sorted in place,
+// and are first made contiguous in the underlying buffer if they wrap around
+// its end.
+func (r *Ring[T]) SortFunc(cmp func(a, b T) int) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if r.Len() <= 1 {
+		return
+	}
+	r.mods++
+	slices.SortFunc(r.linearize(), cmp)
+}
+
+// SortStableFunc sorts the elements of the Ring while keeping the original
+// order of equal elements, as with slices.SortStableFunc.
+func (r *Ring[T]) SortStableFunc(cmp func(a, b T) int) {
+	if debug {
+		defer r.debugCheck()
+	}
+	if r.Len() <= 1 {
+		return
+	}
+	r.mods++
+	slices.SortStableFunc(r.linearize(), cmp)
+}
+
+// IsSortedFunc reports whether the elements of the Ring are sorted in
+// ascending order, with cmp as the comparison function, as with
+// slices.IsSortedFunc. If Ring is nil, then true is returned.
+func (r *Ring[T]) IsSortedFunc(cmp func(a, b T) int) bool {
+	for i := r.Len() - 1; i > 0; i-- {
+		if cmp(r.buf[(r.head+i)%len(r.buf)], r.buf[(r.head+i-1)%len(r.buf)]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BinarySearchFunc searches for target in a Ring sorted in ascending order,
+// as with slices.BinarySearchFunc. It returns the index, compatible with At,
+// where target is found or the index where target would be inserted, and a
+// bool that is true if target is found. The cmp function returns a negative
+// number if the element is less than target, zero if it matches target, and a
+// positive number if it is greater than target.
+func BinarySearchFunc[T, E any](r *Ring[T], target E, cmp func(T, E) int) (int, bool) {
+	n := r.Len()
+	// Define cmp(r[-1], target) < 0 and cmp(r[n], target) >= 0.
+	// Invariant: cmp(r[i-1], target) < 0, cmp(r[j], target) >= 0.
+	i, j := 0, n
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		if cmp(r.buf[(r.head+h)%len(r.buf)], target) < 0 {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i, i < n && cmp(r.buf[(r.head+i)%len(r.buf)], target) == 0
+}
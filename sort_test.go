@@ -0,0 +1,172 @@
+package ring
+
+import (
+	"cmp"
+	"container/heap"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// wrappedRing returns a full Ring whose elements wrap around the end of the
+// underlying buffer, with an empty slot.
+func wrappedRing(items ...int) *Ring[int] {
+	r := New[int](len(items) + 1)
+	for i := 0; i < len(items)/2+1; i++ {
+		r.PushBack(0)
+		r.PopFront()
+	}
+	for _, x := range items {
+		r.PushBack(x)
+	}
+	return r
+}
+
+func TestSortFunc(t *testing.T) {
+	r := wrappedRing(5, 2, 8, 1, 9, 3, 7)
+	if r.head+r.count <= len(r.buf) {
+		t.Fatal("expected ring elements to wrap")
+	}
+	if r.IsSortedFunc(cmp.Compare[int]) {
+		t.Fatal("expected ring to not be sorted")
+	}
+	r.SortFunc(cmp.Compare[int])
+	if !r.IsSortedFunc(cmp.Compare[int]) {
+		t.Fatal("expected ring to be sorted")
+	}
+	for i, x := range []int{1, 2, 3, 5, 7, 8, 9} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+
+	// Ring must remain usable after sorting.
+	r.PushBack(10)
+	r.PushBack(11)
+	if r.Front() != 2 || r.Back() != 11 || r.Len() != 8 {
+		t.Fatal("ring not usable after sorting")
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 20; n++ {
+		r = New[int](16)
+		for i := 0; i < n+rng.Intn(16); i++ {
+			r.PushBack(rng.Intn(100))
+		}
+		expect := r.items()
+		slices.Sort(expect)
+		r.SortFunc(cmp.Compare[int])
+		if !slices.Equal(r.items(), expect) {
+			t.Fatalf("expected %v, got %v", expect, r.items())
+		}
+	}
+
+	var nilRing *Ring[int]
+	nilRing.SortFunc(cmp.Compare[int])
+	if !nilRing.IsSortedFunc(cmp.Compare[int]) {
+		t.Fatal("expected nil ring to be sorted")
+	}
+}
+
+func TestSortStableFunc(t *testing.T) {
+	type event struct {
+		key, seq int
+	}
+	r := New[event](8)
+	for i := 0; i < 12; i++ {
+		r.PushBack(event{key: i % 3, seq: i})
+	}
+	r.SortStableFunc(func(a, b event) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	for i := 1; i < r.Len(); i++ {
+		a, b := r.At(i-1), r.At(i)
+		if a.key > b.key || (a.key == b.key && a.seq > b.seq) {
+			t.Fatal("sort was not stable")
+		}
+	}
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	type event struct {
+		time int
+		name string
+	}
+	r := New[event](6)
+	for i := 0; i < 10; i++ {
+		r.PushBack(event{time: i * 10, name: string(rune('a' + i))})
+	}
+	// ring times: 40 50 60 70 80 90
+	byTime := func(e event, t int) int {
+		return cmp.Compare(e.time, t)
+	}
+
+	i, found := BinarySearchFunc(r, 60, byTime)
+	if !found || i != 2 || r.At(i).name != "g" {
+		t.Fatalf("expected to find 60 at index 2, got %d %v", i, found)
+	}
+	i, found = BinarySearchFunc(r, 65, byTime)
+	if found || i != 3 {
+		t.Fatalf("expected to not find 65 and index 3, got %d %v", i, found)
+	}
+	i, found = BinarySearchFunc(r, 0, byTime)
+	if found || i != 0 {
+		t.Fatalf("expected to not find 0 and index 0, got %d %v", i, found)
+	}
+	i, found = BinarySearchFunc(r, 100, byTime)
+	if found || i != r.Len() {
+		t.Fatalf("expected to not find 100 and index %d, got %d %v", r.Len(), i, found)
+	}
+
+	var nilRing *Ring[event]
+	i, found = BinarySearchFunc(nilRing, 10, byTime)
+	if found || i != 0 {
+		t.Fatal("expected to not find in nil ring")
+	}
+}
+
+func TestSorter(t *testing.T) {
+	r := wrappedRing(5, 2, 8, 1, 9, 3, 7)
+	sort.Sort(Sorter[int]{Ring: r, LessFunc: func(a, b int) bool { return a < b }})
+	for i, x := range []int{1, 2, 3, 5, 7, 8, 9} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+
+	sort.Sort(sort.Reverse(Sorter[int]{Ring: r, LessFunc: func(a, b int) bool { return a < b }}))
+	for i, x := range []int{9, 8, 7, 5, 3, 2, 1} {
+		if r.At(i) != x {
+			t.Errorf("expected %d at index %d, got %d", x, i, r.At(i))
+		}
+	}
+}
+
+func TestSorterHeap(t *testing.T) {
+	h := Sorter[int]{
+		Ring:     New[int](8),
+		LessFunc: func(a, b int) bool { return a < b },
+	}
+	for _, x := range []int{5, 2, 8, 1, 9, 3, 7, 4} {
+		heap.Push(h, x)
+	}
+	assertPanics(t, "should panic when pushing onto full heap", func() {
+		heap.Push(h, 6)
+	})
+	for _, x := range []int{1, 2, 3, 4, 5, 7, 8, 9} {
+		if v := heap.Pop(h).(int); v != x {
+			t.Fatalf("expected %d from heap, got %d", x, v)
+		}
+	}
+	if h.Len() != 0 {
+		t.Fatal("expected empty heap")
+	}
+
+	r := wrappedRing(5, 2, 8, 1, 9, 3, 7)
+	h.Ring = r
+	heap.Init(h)
+	if heap.Pop(h).(int) != 1 || heap.Pop(h).(int) != 2 {
+		t.Fatal("wrong values from initialized heap")
+	}
+}
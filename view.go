@@ -0,0 +1,73 @@
+package ring
+
+// RingView provides read-only access to a Ring. It has the methods of Ring
+// that observe the Ring, but none that modify it, so that code given a
+// RingView cannot change the Ring at compile time.
+//
+// A RingView refers to the Ring it was obtained from, and is not a copy, so it
+// reflects any changes made to the Ring. Methods of RingView behave the same as
+// the Ring methods of the same name. The zero value RingView behaves as an
+// empty Ring.
+type RingView[T any] struct {
+	r *Ring[T]
+}
+
+// View returns a RingView of the Ring. Obtaining a RingView does not allocate
+// or copy the Ring.
+func (r *Ring[T]) View() RingView[T] {
+	return RingView[T]{r: r}
+}
+
+// Len returns the number of elements in the Ring.
+func (v RingView[T]) Len() int { return v.r.Len() }
+
+// Cap returns the capacity of the Ring.
+func (v RingView[T]) Cap() int { return v.r.Cap() }
+
+// Full returns true if the Ring is full.
+func (v RingView[T]) Full() bool { return v.r.Full() }
+
+// At returns the element at index i. It panics if the index is invalid.
+func (v RingView[T]) At(i int) T { return v.r.At(i) }
+
+// AtFrom returns the element at index i, where a negative index counts back
+// from the end of the Ring. It panics if the index is invalid.
+func (v RingView[T]) AtFrom(i int) T { return v.r.AtFrom(i) }
+
+// Front returns the element at the front of the Ring. It panics if the Ring
+// is empty.
+func (v RingView[T]) Front() T { return v.r.Front() }
+
+// Back returns the element at the back of the Ring. It panics if the Ring is
+// empty.
+func (v RingView[T]) Back() T { return v.r.Back() }
+
+// Index returns the index of the first element, searching from front to back,
+// for which f returns true, or -1 if there is none.
+func (v RingView[T]) Index(f func(T) bool) int { return v.r.Index(f) }
+
+// RIndex returns the index of the first element, searching from back to front,
+// for which f returns true, or -1 if there is none.
+func (v RingView[T]) RIndex(f func(T) bool) int { return v.r.RIndex(f) }
+
+// Range calls f for each element, from front to back, until f returns false.
+func (v RingView[T]) Range(f func(item T) bool) { v.r.Range(f) }
+
+// RangeReverse calls f for each element, from back to front, until f returns
+// false.
+func (v RingView[T]) RangeReverse(f func(item T) bool) { v.r.RangeReverse(f) }
+
+// Slice returns a newly allocated slice of the elements from index i up to,
+// but not including, index j.
+func (v RingView[T]) Slice(i, j int) []T { return v.r.Slice(i, j) }
+
+// SliceRange is the same as Slice, except that a negative index counts back
+// from the end of the Ring.
+func (v RingView[T]) SliceRange(i, j int) []T { return v.r.SliceRange(i, j) }
+
+// CopyTo copies elements, in front-to-back order, into dst, and returns the
+// number of elements copied.
+func (v RingView[T]) CopyTo(dst []T) int { return v.r.CopyTo(dst) }
+
+// String returns the elements of the Ring formatted as a slice.
+func (v RingView[T]) String() string { return v.r.String() }
@@ -0,0 +1,79 @@
+package ring
+
+import (
+	"slices"
+	"testing"
+)
+
+// observer is an example of an API that only allows read access to a Ring.
+func observer(v RingView[int]) (sum int) {
+	v.Range(func(item int) bool {
+		sum += item
+		return true
+	})
+	return sum
+}
+
+func TestView(t *testing.T) {
+	r, model := offsetRing(8, 5, 6)
+	v := r.View()
+	if v.Len() != r.Len() || v.Cap() != r.Cap() || v.Full() {
+		t.Fatal("wrong length, capacity, or fullness of view")
+	}
+	if v.Front() != model[0] || v.Back() != model[5] || v.At(2) != model[2] || v.AtFrom(-2) != model[4] {
+		t.Fatal("wrong elements in view")
+	}
+	if !slices.Equal(v.Slice(1, 4), model[1:4]) || !slices.Equal(v.SliceRange(-3, -1), model[3:5]) {
+		t.Fatal("wrong slice of view")
+	}
+	dst := make([]int, 6)
+	if v.CopyTo(dst) != 6 || !slices.Equal(dst, model) {
+		t.Fatal("wrong elements copied from view")
+	}
+	eq3 := func(item int) bool { return item == 3 }
+	if v.Index(eq3) != 2 || v.RIndex(eq3) != 2 {
+		t.Fatal("wrong index in view")
+	}
+	if v.String() != r.String() {
+		t.Fatal("wrong string for view:", v.String())
+	}
+	if observer(v) != 21 {
+		t.Fatal("wrong sum of view elements")
+	}
+	var rev []int
+	v.RangeReverse(func(item int) bool {
+		rev = append(rev, item)
+		return true
+	})
+	if rev[0] != model[5] || len(rev) != 6 {
+		t.Fatal("wrong reverse range of view")
+	}
+
+	// View reflects changes to the ring.
+	r.PushBack(7)
+	r.PushBack(8)
+	if v.Len() != 8 || !v.Full() || v.Back() != 8 || observer(v) != 36 {
+		t.Fatal("view does not reflect changes to ring")
+	}
+
+	var zero RingView[int]
+	if zero.Len() != 0 || zero.Cap() != 0 || zero.Index(eq3) != -1 || observer(zero) != 0 {
+		t.Fatal("zero value view should be empty")
+	}
+	assertPanics(t, "should panic when view is empty", func() {
+		zero.Front()
+	})
+	assertPanics(t, "should panic with index out of range", func() {
+		v.At(8)
+	})
+}
+
+func TestViewAllocs(t *testing.T) {
+	r, _ := offsetRing(8, 0, 4)
+	allocs := testing.AllocsPerRun(100, func() {
+		observer(r.View())
+	})
+	if allocs != 0 {
+		t.Fatal("expected no allocations to obtain view, got", allocs)
+	}
+}